@@ -5,5 +5,5 @@ import (
 )
 
 func newAdler32Hasher() Hasher {
-	return &hasher32{HashFunc: adler32.New}
+	return &hasher32{name: "adler32", HashFunc: adler32.New}
 }
@@ -0,0 +1,139 @@
+package hasher
+
+import (
+	"sort"
+	"strings"
+)
+
+// Algorithm is the name of a built-in hash algorithm. It can be passed to NewHashByName
+// as string(algorithm), e.g. NewHashByName(string(AlgoSHA256)).
+type Algorithm string
+
+// Built-in algorithms.
+const (
+	AlgoMD5          Algorithm = "md5"
+	AlgoSHA1         Algorithm = "sha1"
+	AlgoSHA256       Algorithm = "sha256"
+	AlgoSHA224       Algorithm = "sha224"
+	AlgoSHA384       Algorithm = "sha384"
+	AlgoSHA512       Algorithm = "sha512"
+	AlgoSHA3_256     Algorithm = "sha3-256" //nolint:revive,stylecheck
+	AlgoSHA3_384     Algorithm = "sha3-384" //nolint:revive,stylecheck
+	AlgoSHA3_512     Algorithm = "sha3-512" //nolint:revive,stylecheck
+	AlgoKeccak256    Algorithm = "keccak256"
+	AlgoRipemd160    Algorithm = "ripemd160"
+	AlgoPhash        Algorithm = "phash"
+	AlgoAhash        Algorithm = "ahash"
+	AlgoDhash        Algorithm = "dhash"
+	AlgoFNV32        Algorithm = "fnv32"
+	AlgoFNV32a       Algorithm = "fnv32a"
+	AlgoFNV64        Algorithm = "fnv64"
+	AlgoFNV64a       Algorithm = "fnv64a"
+	AlgoFNV128       Algorithm = "fnv128"
+	AlgoFNV128a      Algorithm = "fnv128a"
+	AlgoBlake2b256   Algorithm = "blake2b-256"
+	AlgoBlake2b512   Algorithm = "blake2b-512"
+	AlgoBlake2s256   Algorithm = "blake2s-256"
+	AlgoBlake3       Algorithm = "blake3"
+	AlgoAdler32      Algorithm = "adler32"
+	AlgoMmh3         Algorithm = "mmh3"
+	AlgoWhirlpool    Algorithm = "whirlpool"
+	AlgoCRC32        Algorithm = "crc32"
+	AlgoCRC32C       Algorithm = "crc32c"
+	AlgoCRC32Koopman Algorithm = "crc32-koopman"
+	AlgoCRC64ISO     Algorithm = "crc64-iso"
+	AlgoCRC64ECMA    Algorithm = "crc64-ecma"
+	AlgoCRC8SMBus    Algorithm = "crc8-smbus"
+	AlgoCRC8Maxim    Algorithm = "crc8-maxim"
+	AlgoXXHash       Algorithm = "xxhash"
+	AlgoXXH3_64      Algorithm = "xxh3-64"  //nolint:revive,stylecheck
+	AlgoXXH3_128     Algorithm = "xxh3-128" //nolint:revive,stylecheck
+	AlgoFarmHash64   Algorithm = "farmhash64"
+	AlgoMetroHash64  Algorithm = "metrohash64"
+	AlgoMetroHash128 Algorithm = "metrohash128"
+)
+
+// DefaultAlgorithm is the algorithm used by NewHash when no algorithm option is given.
+const DefaultAlgorithm = AlgoMD5
+
+// String returns the name of the algorithm.
+func (a Algorithm) String() string {
+	return string(a)
+}
+
+// algorithmAliases maps alternative spellings of algorithm names to the built-in algorithms.
+// Aliases are matched case-insensitively by NewHashByName.
+var algorithmAliases = map[string]Algorithm{
+	"md-5":             AlgoMD5,
+	"sha-1":            AlgoSHA1,
+	"sha-256":          AlgoSHA256,
+	"sha-224":          AlgoSHA224,
+	"sha-384":          AlgoSHA384,
+	"sha-512":          AlgoSHA512,
+	"sha3_256":         AlgoSHA3_256,
+	"sha3_384":         AlgoSHA3_384,
+	"sha3_512":         AlgoSHA3_512,
+	"keccak-256":       AlgoKeccak256,
+	"ripemd-160":       AlgoRipemd160,
+	"rmd160":           AlgoRipemd160,
+	"blake2b":          AlgoBlake2b512,
+	"blake2s":          AlgoBlake2s256,
+	"murmur3":          AlgoMmh3,
+	"xxh64":            AlgoXXHash,
+	"xxh3":             AlgoXXH3_64,
+	"xxh128":           AlgoXXH3_128,
+	"farmhash":         AlgoFarmHash64,
+	"metrohash":        AlgoMetroHash64,
+	"crc-32":           AlgoCRC32,
+	"crc32-ieee":       AlgoCRC32,
+	"crc32-castagnoli": AlgoCRC32C,
+	"crc-32c":          AlgoCRC32C,
+	"crc64":            AlgoCRC64ECMA,
+	"crc-64":           AlgoCRC64ECMA,
+	"crc8":             AlgoCRC8SMBus,
+	"crc-8":            AlgoCRC8SMBus,
+	"perceptual":       AlgoPhash,
+	"average-hash":     AlgoAhash,
+	"difference-hash":  AlgoDhash,
+	"fnv1-32":          AlgoFNV32,
+	"fnv1a-32":         AlgoFNV32a,
+	"fnv1-64":          AlgoFNV64,
+	"fnv1a-64":         AlgoFNV64a,
+	"fnv1-128":         AlgoFNV128,
+	"fnv1a-128":        AlgoFNV128a,
+}
+
+// resolveAlgorithmName returns the registered name for name. A registered name is returned as-is.
+// Otherwise, name is matched case-insensitively against the registered names and the aliases.
+// The caller must hold the registry lock.
+func resolveAlgorithmName(name string) string {
+	if _, ok := registry.factories[name]; ok {
+		return name
+	}
+	lower := strings.ToLower(name)
+	if alias, ok := algorithmAliases[lower]; ok {
+		return string(alias)
+	}
+	return lower
+}
+
+// ListAlgorithms returns every name accepted by NewHashByName in alphabetical order:
+// the registered algorithms (see AvailableAlgorithms) and the aliases of the built-in algorithms,
+// such as "sha-256" for "sha256".
+// Aliases of unregistered algorithms are omitted.
+func ListAlgorithms() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.factories)+len(algorithmAliases))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	for alias, algo := range algorithmAliases {
+		if _, ok := registry.factories[string(algo)]; ok {
+			names = append(names, alias)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
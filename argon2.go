@@ -0,0 +1,104 @@
+package hasher
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Default Argon2id parameters, following the second recommended option of RFC 9106.
+const (
+	defaultArgon2Time    = 3
+	defaultArgon2Memory  = 64 * 1024
+	defaultArgon2Threads = 4
+	defaultArgon2KeyLen  = 32
+)
+
+// argon2Hasher is a Hasher for the Argon2id password hashing algorithm.
+// Unlike bcrypt, the salt is fixed by the caller, so the same password and parameters always
+// produce the same hash.
+type argon2Hasher struct {
+	salt    []byte
+	time    uint32
+	memory  uint32
+	threads uint8
+	keyLen  uint32
+}
+
+// newArgon2Hasher creates a new Hasher instance for Argon2id. Zero parameters are replaced by the defaults.
+func newArgon2Hasher(salt []byte, time, memory uint32, threads uint8, keyLen uint32) *argon2Hasher {
+	a := &argon2Hasher{salt: bytes.Clone(salt), time: time, memory: memory, threads: threads, keyLen: keyLen}
+	if a.time == 0 {
+		a.time = defaultArgon2Time
+	}
+	if a.memory == 0 {
+		a.memory = defaultArgon2Memory
+	}
+	if a.threads == 0 {
+		a.threads = defaultArgon2Threads
+	}
+	if a.keyLen == 0 {
+		a.keyLen = defaultArgon2KeyLen
+	}
+	return a
+}
+
+// Name returns the name of the algorithm.
+func (a *argon2Hasher) Name() string {
+	return "argon2id"
+}
+
+// size returns the length of the derived key.
+func (a *argon2Hasher) size() int {
+	return int(a.keyLen)
+}
+
+// GenHashFromString derives a key from a password with Argon2id.
+func (a *argon2Hasher) GenHashFromString(s string) ([]byte, error) {
+	return argon2.IDKey([]byte(s), a.salt, a.time, a.memory, a.threads, a.keyLen), nil
+}
+
+// GenHashFromBytes derives a key from a password given as a byte slice with Argon2id.
+func (a *argon2Hasher) GenHashFromBytes(b []byte) ([]byte, error) {
+	return argon2.IDKey(b, a.salt, a.time, a.memory, a.threads, a.keyLen), nil
+}
+
+// CmpHashAndBytes compares a hash and the key derived from a password given as a byte slice in constant time.
+// If the password does not match the hash, ErrHashMismatch is returned.
+func (a *argon2Hasher) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := a.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// GenHashFromIOReader always returns ErrStreamingNotSupported because Argon2id only hashes passwords.
+func (a *argon2Hasher) GenHashFromIOReader(_ io.Reader) ([]byte, error) {
+	return nil, ErrStreamingNotSupported
+}
+
+// CmpHashAndString compares a hash and the key derived from a password in constant time.
+// If the password does not match the hash, ErrHashMismatch is returned.
+func (a *argon2Hasher) CmpHashAndString(hashA []byte, s string) error {
+	hashB, err := a.GenHashFromString(s)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader always returns ErrStreamingNotSupported because Argon2id only hashes passwords.
+func (a *argon2Hasher) CmpHashAndIOReader(_ []byte, _ io.Reader) error {
+	return ErrStreamingNotSupported
+}
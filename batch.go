@@ -0,0 +1,53 @@
+package hasher
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GenerateBatch generates the hash of each input, as Generate does, and returns the hashes and errors
+// aligned with inputs: digests[i] and errs[i] are the result of inputs[i]. A failing input does not stop
+// the others. The inputs are hashed by as many goroutines as set by WithConcurrency, one by default.
+// With WithObserver, the batch is reported as one observation of the bytes of all inputs.
+func (h *Hash) GenerateBatch(inputs []any) ([][]byte, []error) {
+	digests := make([][]byte, len(inputs))
+	errs := make([]error, len(inputs))
+
+	// The inputs are hashed without the observer, and the batch is reported once with the bytes of all inputs.
+	var hashed atomic.Int64
+	defer func(start time.Time) { h.observeOperation(start, hashed.Load()) }(time.Now())
+	inner := h.internal()
+	if h.observer != nil {
+		inner.observer = func(_ string, n int64, _ time.Duration) { hashed.Add(n) }
+	}
+
+	workers := h.concurrency
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+	if workers <= 1 {
+		for i, input := range inputs {
+			digests[i], errs[i] = inner.Generate(input)
+		}
+		return digests, errs
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				digests[i], errs[i] = inner.Generate(inputs[i])
+			}
+		}()
+	}
+	for i := range inputs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return digests, errs
+}
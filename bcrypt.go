@@ -0,0 +1,63 @@
+package hasher
+
+import (
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptHasher is a Hasher for the bcrypt password hashing algorithm.
+// A bcrypt hash embeds its own random salt, so hashing the same password twice yields
+// different hashes; use Compare to verify a password instead of comparing bytes.
+type bcryptHasher struct {
+	cost int
+}
+
+// Name returns the name of the algorithm.
+func (b *bcryptHasher) Name() string {
+	return "bcrypt"
+}
+
+// randomized marks bcrypt as randomized: every hash has a new random salt.
+func (b *bcryptHasher) randomized() {}
+
+// GenHashFromString generates a bcrypt hash from a password.
+func (b *bcryptHasher) GenHashFromString(s string) ([]byte, error) {
+	return bcrypt.GenerateFromPassword([]byte(s), b.cost)
+}
+
+// GenHashFromBytes generates a bcrypt hash from a password given as a byte slice.
+func (b *bcryptHasher) GenHashFromBytes(p []byte) ([]byte, error) {
+	return bcrypt.GenerateFromPassword(p, b.cost)
+}
+
+// CmpHashAndBytes compares a bcrypt hash and a password given as a byte slice.
+// If the password does not match the hash, ErrHashMismatch is returned.
+func (b *bcryptHasher) CmpHashAndBytes(hash []byte, p []byte) error {
+	err := bcrypt.CompareHashAndPassword(hash, p)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrHashMismatch
+	}
+	return err
+}
+
+// GenHashFromIOReader always returns ErrStreamingNotSupported because bcrypt only hashes passwords.
+func (b *bcryptHasher) GenHashFromIOReader(_ io.Reader) ([]byte, error) {
+	return nil, ErrStreamingNotSupported
+}
+
+// CmpHashAndString compares a bcrypt hash and a password.
+// If the password does not match the hash, ErrHashMismatch is returned.
+func (b *bcryptHasher) CmpHashAndString(hash []byte, s string) error {
+	err := bcrypt.CompareHashAndPassword(hash, []byte(s))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrHashMismatch
+	}
+	return err
+}
+
+// CmpHashAndIOReader always returns ErrStreamingNotSupported because bcrypt only hashes passwords.
+func (b *bcryptHasher) CmpHashAndIOReader(_ []byte, _ io.Reader) error {
+	return ErrStreamingNotSupported
+}
@@ -0,0 +1,128 @@
+package hasher
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+)
+
+// blake2Hasher is a Hasher for the unkeyed BLAKE2b and BLAKE2s algorithms.
+type blake2Hasher struct {
+	name string
+	// newFunc is a constructor of golang.org/x/crypto/blake2b or blake2s, e.g. blake2b.New256.
+	newFunc func(key []byte) (hash.Hash, error)
+}
+
+// newBlake2b256Hasher creates a new Hasher instance for BLAKE2b-256 algorithm.
+func newBlake2b256Hasher() Hasher {
+	return &blake2Hasher{name: "blake2b-256", newFunc: blake2b.New256}
+}
+
+// newBlake2b512Hasher creates a new Hasher instance for BLAKE2b-512 algorithm.
+func newBlake2b512Hasher() Hasher {
+	return &blake2Hasher{name: "blake2b-512", newFunc: blake2b.New512}
+}
+
+// newBlake2s256Hasher creates a new Hasher instance for BLAKE2s-256 algorithm.
+func newBlake2s256Hasher() Hasher {
+	return &blake2Hasher{name: "blake2s-256", newFunc: blake2s.New256}
+}
+
+// newBlake2b512KeyedHasher creates a new Hasher instance for BLAKE2b-512 keyed with key.
+// If the key is longer than blake2b.Size bytes, the Hasher returns ErrInvalidKeyLength.
+func newBlake2b512KeyedHasher(key []byte) Hasher {
+	if len(key) > blake2b.Size {
+		return &failingHasher{name: "blake2b-512-keyed", err: fmt.Errorf("%w: %d bytes, must be at most %d bytes", ErrInvalidKeyLength, len(key), blake2b.Size)}
+	}
+
+	key = bytes.Clone(key)
+	return &blake2Hasher{
+		name: "blake2b-512-keyed",
+		newFunc: func(_ []byte) (hash.Hash, error) {
+			return blake2b.New512(key)
+		},
+	}
+}
+
+// Name returns the name of the algorithm.
+func (b *blake2Hasher) Name() string {
+	return b.name
+}
+
+// newHash returns a new hash.Hash for the BLAKE2 algorithm.
+// The BLAKE2 constructors only fail for keys that are too long, which are rejected beforehand, so the error is ignored.
+func (b *blake2Hasher) newHash() hash.Hash {
+	h, _ := b.newFunc(nil) //nolint:errcheck
+	return h
+}
+
+// GenHashFromString generates a hash from a string using the BLAKE2 algorithm.
+func (b *blake2Hasher) GenHashFromString(s string) ([]byte, error) {
+	h := b.newHash()
+	if _, err := h.Write([]byte(s)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// GenHashFromBytes generates a hash from a byte slice using the BLAKE2 algorithm.
+func (b *blake2Hasher) GenHashFromBytes(p []byte) ([]byte, error) {
+	h := b.newHash()
+	if _, err := h.Write(p); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the BLAKE2 algorithm.
+func (b *blake2Hasher) CmpHashAndBytes(hashA []byte, p []byte) error {
+	hashB, err := b.GenHashFromBytes(p)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// GenHashFromIOReader generates a hash from an io.Reader using the BLAKE2 algorithm.
+func (b *blake2Hasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	h := b.newHash()
+	if _, err := copyBuffer(h, r, 0); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndString compares a hash and a string using the BLAKE2 algorithm.
+func (b *blake2Hasher) CmpHashAndString(hashA []byte, s string) error {
+	hashB, err := b.GenHashFromString(s)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader compares a hash and an io.Reader using the BLAKE2 algorithm.
+func (b *blake2Hasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := b.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
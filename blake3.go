@@ -1,29 +1,73 @@
 package hasher
 
 import (
-	"bytes"
+	"crypto/subtle"
+	"hash"
 	"io"
 
 	"lukechampine.com/blake3"
 )
 
-type blake3Hasher struct{}
+// blake3Hasher is a Hasher for the BLAKE3 algorithm in the hash and keyed hash modes.
+type blake3Hasher struct {
+	// key is the 32-byte key of the keyed hash mode. If it is nil, the hash is unkeyed.
+	key []byte
+}
+
+// blake3Size is the length of a BLAKE3 hash generated by this package in bytes.
+const blake3Size = 64
+
+// Name returns the name of the algorithm: "blake3", or "blake3-keyed" in the keyed hash mode.
+func (b *blake3Hasher) Name() string {
+	if b.key != nil {
+		return "blake3-keyed"
+	}
+	return "blake3"
+}
+
+// newHash returns a new hash.Hash for the blake3 algorithm.
+// The hash length is 64 bytes.
+func (b *blake3Hasher) newHash() hash.Hash {
+	return blake3.New(blake3Size, b.key)
+}
 
 // GenHashFromString generates a hash from a string using the blake3 algorithm.
 // The hash length is 64 bytes.
 func (b *blake3Hasher) GenHashFromString(s string) ([]byte, error) {
-	h := blake3.New(64, nil)
+	h := b.newHash()
 	if _, err := h.Write([]byte(s)); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
 }
 
+// GenHashFromBytes generates a hash from a byte slice using the blake3 algorithm.
+func (b *blake3Hasher) GenHashFromBytes(p []byte) ([]byte, error) {
+	h := b.newHash()
+	if _, err := h.Write(p); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the blake3 algorithm.
+func (b *blake3Hasher) CmpHashAndBytes(hashA []byte, p []byte) error {
+	hashB, err := b.GenHashFromBytes(p)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
 // GenHashFromIOReader generates a hash from an io.Reader using the blake3 algorithm.
 // The hash length is 64 bytes.
 func (b *blake3Hasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
-	h := blake3.New(64, nil)
-	if _, err := io.Copy(h, r); err != nil {
+	h := b.newHash()
+	if _, err := copyBuffer(h, r, 0); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
@@ -36,7 +80,7 @@ func (b *blake3Hasher) CmpHashAndString(hashA []byte, s string) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -49,7 +93,76 @@ func (b *blake3Hasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// blake3DeriveKeyHasher is a Hasher for the key derivation mode of BLAKE3.
+// The input is the key material, and the hash is the key derived from it for the context.
+type blake3DeriveKeyHasher struct {
+	context string
+}
+
+// Name returns the name of the algorithm.
+func (b *blake3DeriveKeyHasher) Name() string {
+	return "blake3-derive-key"
+}
+
+// size returns the length of a derived key.
+func (b *blake3DeriveKeyHasher) size() int {
+	return blake3Size
+}
+
+// GenHashFromBytes derives a key from key material given as a byte slice.
+func (b *blake3DeriveKeyHasher) GenHashFromBytes(p []byte) ([]byte, error) {
+	subKey := make([]byte, blake3Size)
+	blake3.DeriveKey(subKey, b.context, p)
+	return subKey, nil
+}
+
+// GenHashFromString derives a key from key material given as a string.
+func (b *blake3DeriveKeyHasher) GenHashFromString(s string) ([]byte, error) {
+	return b.GenHashFromBytes([]byte(s))
+}
+
+// GenHashFromIOReader derives a key from key material read from an io.Reader.
+// The key derivation mode cannot hash incrementally, so the key material is read into memory.
+func (b *blake3DeriveKeyHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	p, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return b.GenHashFromBytes(p)
+}
+
+// CmpHashAndBytes compares a hash and the key derived from a byte slice.
+func (b *blake3DeriveKeyHasher) CmpHashAndBytes(hashA []byte, p []byte) error {
+	hashB, err := b.GenHashFromBytes(p)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndString compares a hash and the key derived from a string.
+func (b *blake3DeriveKeyHasher) CmpHashAndString(hashA []byte, s string) error {
+	return b.CmpHashAndBytes(hashA, []byte(s))
+}
+
+// CmpHashAndIOReader compares a hash and the key derived from an io.Reader.
+func (b *blake3DeriveKeyHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := b.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -0,0 +1,92 @@
+package hasher
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+// defaultBufferSize is the size of the copy buffer used when hashing an io.Reader.
+// It matches the size of the buffer that io.Copy allocates internally.
+const defaultBufferSize = 32 * 1024
+
+// errInvalidWrite is returned by copyWithBuffer when a Write returns an impossible count.
+var errInvalidWrite = errors.New("invalid write result")
+
+// bufferPools holds a *sync.Pool of copy buffers for each buffer size in use.
+var bufferPools sync.Map
+
+// bufferPool returns the pool of copy buffers for the given size.
+func bufferPool(size int) *sync.Pool {
+	if p, ok := bufferPools.Load(size); ok {
+		return p.(*sync.Pool) //nolint:forcetypeassert
+	}
+	p, _ := bufferPools.LoadOrStore(size, &sync.Pool{
+		New: func() any {
+			b := make([]byte, size)
+			return &b
+		},
+	})
+	return p.(*sync.Pool) //nolint:forcetypeassert
+}
+
+// copyBuffer copies from src to dst using a pooled buffer of the given size.
+// If size is not positive, defaultBufferSize is used. The buffer is returned to
+// the pool once the copy completes; hash.Hash implementations never retain the
+// slices passed to Write, so reusing the buffer does not change the result.
+// If src is an in-memory reader (*bytes.Reader, *bytes.Buffer, or *strings.Reader), its content is
+// written to dst directly with io.WriterTo, so no buffer is taken from the pool. Other readers are
+// always copied through the pooled buffer, even if they implement io.WriterTo: *os.File does since
+// Go 1.22, but its WriteTo allocates a new buffer on every call and ignores the configured size.
+func copyBuffer(dst io.Writer, src io.Reader, size int) (int64, error) {
+	switch src := src.(type) {
+	case *bytes.Reader:
+		return src.WriteTo(dst)
+	case *bytes.Buffer:
+		return src.WriteTo(dst)
+	case *strings.Reader:
+		return src.WriteTo(dst)
+	}
+
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	pool := bufferPool(size)
+	buf := pool.Get().(*[]byte) //nolint:forcetypeassert
+	defer pool.Put(buf)
+	return copyWithBuffer(dst, src, *buf)
+}
+
+// copyWithBuffer copies from src to dst through buf until io.EOF or an error, like io.CopyBuffer.
+// Unlike io.CopyBuffer, it never uses io.WriterTo or io.ReaderFrom, so buf is always used and
+// src and dst do not have to be wrapped, which would allocate on every call.
+func copyWithBuffer(dst io.Writer, src io.Reader, buf []byte) (int64, error) {
+	var written int64
+	for {
+		nr, rerr := src.Read(buf)
+		if nr > 0 {
+			nw, werr := dst.Write(buf[:nr])
+			if nw < 0 || nw > nr {
+				nw = 0
+				if werr == nil {
+					werr = errInvalidWrite
+				}
+			}
+			written += int64(nw)
+			if werr != nil {
+				return written, werr
+			}
+			if nw != nr {
+				return written, io.ErrShortWrite
+			}
+		}
+		if rerr == io.EOF {
+			return written, nil
+		}
+		if rerr != nil {
+			return written, rerr
+		}
+	}
+}
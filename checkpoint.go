@@ -0,0 +1,156 @@
+package hasher
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// checkpoint is the configuration of periodic hash state checkpoints.
+type checkpoint struct {
+	path  string
+	every int64
+}
+
+// checkpointWriter is an io.Writer that writes to a hash and saves the hash state to a
+// checkpoint file every time at least every bytes have been written since the last save.
+// The checkpoint file holds the number of bytes hashed as a big-endian uint64 followed by
+// the marshaled hash state.
+type checkpointWriter struct {
+	hash   hash.Hash
+	path   string
+	every  int64
+	offset int64
+	saved  int64
+}
+
+// Write writes p to the hash and saves a checkpoint if it is due.
+func (w *checkpointWriter) Write(p []byte) (int, error) {
+	n, err := w.hash.Write(p)
+	w.offset += int64(n)
+	if err != nil {
+		return n, err
+	}
+	if w.every > 0 && w.offset-w.saved >= w.every {
+		if err := w.save(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// save writes the current hash state to the checkpoint file. The file is replaced atomically,
+// so an interruption while saving leaves the previous checkpoint intact.
+func (w *checkpointWriter) save() error {
+	state, err := w.hash.(encoding.BinaryMarshaler).MarshalBinary() //nolint:forcetypeassert
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, 8, 8+len(state))
+	binary.BigEndian.PutUint64(data, uint64(w.offset))
+	data = append(data, state...)
+
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, w.path); err != nil {
+		return err
+	}
+	w.saved = w.offset
+	return nil
+}
+
+// marshalableHash returns a new hash.Hash of the configured algorithm whose state can be saved and restored.
+// If the algorithm cannot marshal its state, ErrStateNotSupported is returned.
+func (h *Hash) marshalableHash() (hash.Hash, error) {
+	s, ok := h.hasher.(streamer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStateNotSupported, algorithmName(h.hasher))
+	}
+	hs := s.newHash()
+	if _, ok := hs.(encoding.BinaryMarshaler); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStateNotSupported, algorithmName(h.hasher))
+	}
+	if _, ok := hs.(encoding.BinaryUnmarshaler); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStateNotSupported, algorithmName(h.hasher))
+	}
+	return hs, nil
+}
+
+// genHashWithCheckpoint generates a hash from r, saving checkpoints as configured by WithCheckpoint.
+func (h *Hash) genHashWithCheckpoint(r io.Reader) ([]byte, error) {
+	hs, err := h.marshalableHash()
+	if err != nil {
+		return nil, err
+	}
+	return h.copyWithCheckpoint(hs, 0, r)
+}
+
+// copyWithCheckpoint copies r into hs, which has already hashed offset bytes, and returns the hash.
+// Checkpoints are saved if WithCheckpoint is configured. Once r is fully hashed, the checkpoint
+// file is removed because there is nothing left to resume.
+func (h *Hash) copyWithCheckpoint(hs hash.Hash, offset int64, r io.Reader) ([]byte, error) {
+	if h.checkpoint == nil {
+		if _, err := copyBuffer(hs, r, h.bufferSize); err != nil {
+			return nil, err
+		}
+		return hs.Sum(nil), nil
+	}
+
+	w := &checkpointWriter{
+		hash:   hs,
+		path:   h.checkpoint.path,
+		every:  h.checkpoint.every,
+		offset: offset,
+		saved:  offset,
+	}
+	if _, err := copyBuffer(w, r, h.bufferSize); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(h.checkpoint.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return hs.Sum(nil), nil
+}
+
+// ResumeFrom restores the hash state saved in the checkpoint file at path by WithCheckpoint,
+// and continues hashing r. r must yield the whole input from the beginning: the bytes that
+// were already hashed are skipped, by seeking if r is an io.Seeker. Checkpoints keep being
+// saved if WithCheckpoint is configured.
+// If the configured algorithm cannot restore its state, ErrStateNotSupported is returned.
+// If the checkpoint file is malformed or was saved by another algorithm, ErrInvalidCheckpoint is returned.
+func (h *Hash) ResumeFrom(path string, r io.Reader) ([]byte, error) {
+	hs, err := h.marshalableHash()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 {
+		return nil, fmt.Errorf("%w: %s is too short", ErrInvalidCheckpoint, path)
+	}
+	offset := int64(binary.BigEndian.Uint64(data[:8]))
+	if err := hs.(encoding.BinaryUnmarshaler).UnmarshalBinary(data[8:]); err != nil { //nolint:forcetypeassert
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCheckpoint, err)
+	}
+
+	if s, ok := r.(io.Seeker); ok {
+		if _, err := s.Seek(offset, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	} else if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+		return nil, err
+	}
+	return h.copyWithCheckpoint(hs, offset, h.wrapReader(r))
+}
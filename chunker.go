@@ -0,0 +1,99 @@
+package hasher
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"time"
+)
+
+// buzhashWindow is the number of bytes the rolling hash of a Chunker covers.
+const buzhashWindow = 48
+
+// buzhashTable maps each byte to a pseudo-random 32-bit value for the buzhash rolling hash.
+// It is generated from a fixed seed, so chunk boundaries are the same in every process.
+var buzhashTable = func() [256]uint32 {
+	var t [256]uint32
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range t {
+		// splitmix64
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		t[i] = uint32(z ^ (z >> 31))
+	}
+	return t
+}()
+
+// Chunker splits an io.Reader into content-defined chunks and hashes each chunk with a Hash.
+// A chunk ends where the buzhash rolling hash of the last 48 bytes matches a pattern, so boundaries
+// depend on the content rather than on offsets: inserting or removing bytes only changes the chunks
+// around the edit, which lets identical content be found and deduplicated across streams.
+// A Chunker is not safe for concurrent use.
+type Chunker struct {
+	h *Hash
+	// inner hashes the chunks without the observer of h, which is called once per chunk by Next instead.
+	inner   *Hash
+	r       *bufio.Reader
+	minSize int
+	maxSize int
+	// mask selects the bits of the rolling hash that must be zero at a boundary.
+	mask uint32
+}
+
+// NewChunker returns a Chunker that splits r into chunks of minSize to maxSize bytes and hashes them with h.
+// A boundary is found on average avgSize (rounded down to a power of two) bytes after minSize.
+// Only the last chunk can be shorter than minSize.
+// If the sizes are not 0 < minSize <= avgSize <= maxSize, ErrInvalidChunkSize is returned.
+func NewChunker(h *Hash, r io.Reader, minSize, maxSize, avgSize int) (*Chunker, error) {
+	if minSize <= 0 || avgSize < minSize || maxSize < avgSize {
+		return nil, fmt.Errorf("%w: min %d, avg %d, max %d", ErrInvalidChunkSize, minSize, avgSize, maxSize)
+	}
+	return &Chunker{
+		h:       h,
+		inner:   h.internal(),
+		r:       bufio.NewReader(r),
+		minSize: minSize,
+		maxSize: maxSize,
+		mask:    uint32(1)<<(bits.Len(uint(avgSize))-1) - 1,
+	}, nil
+}
+
+// Next returns the next chunk and its hash. The chunk is a new slice that the caller may keep.
+// At the end of the input, Next returns io.EOF.
+func (c *Chunker) Next() (chunk, digest []byte, err error) {
+	chunk = make([]byte, 0, c.minSize)
+	start := time.Now()
+	var hash uint32
+	for len(chunk) < c.maxSize {
+		b, err := c.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chunk = append(chunk, b)
+		hash = bits.RotateLeft32(hash, 1) ^ buzhashTable[b]
+		if n := len(chunk); n > buzhashWindow {
+			hash ^= bits.RotateLeft32(buzhashTable[chunk[n-1-buzhashWindow]], buzhashWindow)
+		}
+		if len(chunk) >= c.minSize && hash&c.mask == 0 {
+			break
+		}
+	}
+	if len(chunk) == 0 {
+		return nil, nil, io.EOF
+	}
+
+	digest, err = c.inner.Generate(chunk)
+	c.h.observeOperation(start, int64(len(chunk)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return chunk, digest, nil
+}
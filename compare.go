@@ -0,0 +1,88 @@
+package hasher
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// StreamsEqual reports whether a and b have the same content.
+// The readers are compared chunk by chunk, and StreamsEqual returns false as soon as
+// a difference is found without reading the rest of either reader. Comparing two streams
+// directly is faster than hashing both when they differ, and it is exact.
+// It returns true only if both readers end at the same position with the same content.
+func StreamsEqual(a, b io.Reader) (bool, error) {
+	pool := bufferPool(defaultBufferSize)
+	bufA := pool.Get().(*[]byte) //nolint:forcetypeassert
+	defer pool.Put(bufA)
+	bufB := pool.Get().(*[]byte) //nolint:forcetypeassert
+	defer pool.Put(bufB)
+
+	for {
+		na, errA := io.ReadFull(a, *bufA)
+		if errA != nil && !isEOF(errA) {
+			return false, errA
+		}
+		nb, errB := io.ReadFull(b, *bufB)
+		if errB != nil && !isEOF(errB) {
+			return false, errB
+		}
+
+		if na != nb || !bytes.Equal((*bufA)[:na], (*bufB)[:nb]) {
+			return false, nil
+		}
+		// Both chunks have the same length, so if one reader ended, so did the other.
+		if errA != nil {
+			return true, nil
+		}
+	}
+}
+
+// isEOF reports whether err indicates that io.ReadFull reached the end of the reader.
+func isEOF(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// CompareAny compares the input with several acceptable hashes, e.g. during a key or hash rotation window.
+// If the input matches any of expected, nil is returned. Otherwise, ErrHashMismatch is returned.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Compare.
+// Each hash is checked by the algorithm as Compare does, so salted algorithms such as bcrypt are supported.
+// If the algorithm is backed by a hash.Hash, the input is hashed once; otherwise an io.Reader is read into
+// memory so that it can be checked against every hash.
+// As with Compare, ErrInvalidHashLength is returned without reading the input if the length of any of
+// expected is wrong, and errors are prefixed with the name of the algorithm.
+func (h *Hash) CompareAny(expected [][]byte, input any) error {
+	for _, e := range expected {
+		if err := h.checkHashLength(e); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := h.hasher.(streamer); ok {
+		digest, err := h.Generate(input)
+		if err != nil {
+			return err
+		}
+		for _, e := range expected {
+			if subtle.ConstantTimeCompare(e, digest) == 1 {
+				return nil
+			}
+		}
+		return h.wrapError(ErrHashMismatch)
+	}
+
+	buffered, err := h.bufferInput(input)
+	if err != nil {
+		return h.wrapError(err)
+	}
+	for _, e := range expected {
+		if err := h.compare(e, buffered); !errors.Is(err, ErrHashMismatch) {
+			if err != nil {
+				return h.wrapError(err)
+			}
+			return nil
+		}
+	}
+	return h.wrapError(ErrHashMismatch)
+}
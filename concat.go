@@ -0,0 +1,116 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"image"
+	"io"
+	"strings"
+)
+
+// concatHasher is a Hasher that concatenates the digests of several hashers over the same input.
+type concatHasher struct {
+	hashers []Hasher
+}
+
+// newConcatHasher creates a new concatHasher. Each option selects one algorithm.
+func newConcatHasher(opts ...Option) *concatHasher {
+	c := &concatHasher{}
+	for _, opt := range opts {
+		c.hashers = append(c.hashers, NewHash(opt).hasher)
+	}
+	return c
+}
+
+// Name returns the names of the concatenated algorithms joined with "+", e.g. "md5+sha1".
+func (c *concatHasher) Name() string {
+	names := make([]string, 0, len(c.hashers))
+	for _, h := range c.hashers {
+		names = append(names, algorithmName(h))
+	}
+	return strings.Join(names, "+")
+}
+
+// size returns the total length of the concatenated hashes, or 0 if the length of any of them is not fixed.
+func (c *concatHasher) size() int {
+	total := 0
+	for _, h := range c.hashers {
+		n := digestSize(h)
+		if n == 0 {
+			return 0
+		}
+		total += n
+	}
+	return total
+}
+
+// GenHashFromString generates the concatenated hashes of a string.
+func (c *concatHasher) GenHashFromString(s string) ([]byte, error) {
+	var digest []byte
+	for _, h := range c.hashers {
+		b, err := h.GenHashFromString(s)
+		if err != nil {
+			return nil, err
+		}
+		digest = append(digest, b...)
+	}
+	return digest, nil
+}
+
+// GenHashFromIOReader generates the concatenated hashes of an io.Reader.
+// If every algorithm is backed by a hash.Hash, the reader is read once and fanned out to all of them.
+// Otherwise, the content is buffered in memory and hashed by each algorithm in turn.
+func (c *concatHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	digests, errs, err := hashEach(c.hashers, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var digest []byte
+	for i, d := range digests {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		digest = append(digest, d...)
+	}
+	return digest, nil
+}
+
+// genHashFromImage generates the concatenated hashes of a decoded image.
+// If any of the algorithms cannot hash an image.Image, ErrUnsupportedInputType is returned.
+func (c *concatHasher) genHashFromImage(img image.Image) ([]byte, error) {
+	var digest []byte
+	for _, h := range c.hashers {
+		b, err := hashImage(h, img)
+		if err != nil {
+			return nil, err
+		}
+		digest = append(digest, b...)
+	}
+	return digest, nil
+}
+
+// CmpHashAndString compares a hash and the concatenated hashes of a string.
+func (c *concatHasher) CmpHashAndString(hashA []byte, s string) error {
+	hashB, err := c.GenHashFromString(s)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader compares a hash and the concatenated hashes of an io.Reader.
+func (c *concatHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := c.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
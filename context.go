@@ -0,0 +1,35 @@
+package hasher
+
+import (
+	"context"
+	"io"
+)
+
+// contextReader is an io.Reader that stops reading once its context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read returns the error of the context if it is done, and reads from the underlying reader otherwise.
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// GenerateContext generates a hash from the input like Generate, but stops when ctx is done.
+// An io.Reader is hashed in bounded chunks (see WithBufferSize), and ctx is checked before
+// each chunk is read, so cancelling ctx stops hashing a large input promptly; a single read that
+// blocks is not interrupted. For string input, ctx is checked once before hashing.
+// If ctx is done, its error (context.Canceled or context.DeadlineExceeded) is returned, prefixed with the
+// name of the algorithm as the other errors of Generate are. A cancelled read is not retried, even with WithRetry.
+func (h *Hash) GenerateContext(ctx context.Context, input any) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, h.wrapError(err)
+	}
+	return h.generate(input, func(r io.Reader) ([]byte, error) {
+		return h.genHashFromIOReader(&contextReader{ctx: ctx, r: r})
+	})
+}
@@ -1,8 +1,28 @@
 package hasher
 
-import "hash/crc32"
+import (
+	"hash"
+	"hash/crc32"
+)
 
 // newCRC32Hasher creates a new Hasher instance for CRC32 algorithm.
 func newCRC32Hasher() Hasher {
-	return &hasher32{HashFunc: crc32.NewIEEE}
+	return &hasher32{name: "crc32", HashFunc: crc32.NewIEEE}
+}
+
+// crc32CastagnoliTable is the table of the Castagnoli polynomial.
+// crc32.Update uses the CRC32C instructions of the CPU with this table if they are available.
+var crc32CastagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// newCRC32CastagnoliHasher creates a new Hasher instance for CRC32 algorithm with the Castagnoli polynomial (CRC32C).
+func newCRC32CastagnoliHasher() Hasher {
+	return &hasher32{name: "crc32c", HashFunc: func() hash.Hash32 { return crc32.New(crc32CastagnoliTable) }}
+}
+
+// crc32KoopmanTable is the table of the Koopman polynomial.
+var crc32KoopmanTable = crc32.MakeTable(crc32.Koopman)
+
+// newCRC32KoopmanHasher creates a new Hasher instance for CRC32 algorithm with the Koopman polynomial.
+func newCRC32KoopmanHasher() Hasher {
+	return &hasher32{name: "crc32-koopman", HashFunc: func() hash.Hash32 { return crc32.New(crc32KoopmanTable) }}
 }
@@ -0,0 +1,22 @@
+package hasher
+
+import (
+	"hash"
+	"hash/crc64"
+)
+
+// crc64ISOTable is the table of the ISO polynomial, defined in ISO 3309 and used in HDLC.
+var crc64ISOTable = crc64.MakeTable(crc64.ISO)
+
+// newCRC64ISOHasher creates a new Hasher instance for CRC64 algorithm with the ISO polynomial.
+func newCRC64ISOHasher() Hasher {
+	return &hasher64{name: "crc64-iso", HashFunc: func() hash.Hash64 { return crc64.New(crc64ISOTable) }}
+}
+
+// crc64ECMATable is the table of the ECMA polynomial, defined in ECMA 182 and used by the xz format.
+var crc64ECMATable = crc64.MakeTable(crc64.ECMA)
+
+// newCRC64ECMAHasher creates a new Hasher instance for CRC64 algorithm with the ECMA polynomial.
+func newCRC64ECMAHasher() Hasher {
+	return &hasher64{name: "crc64-ecma", HashFunc: func() hash.Hash64 { return crc64.New(crc64ECMATable) }}
+}
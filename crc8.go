@@ -0,0 +1,122 @@
+package hasher
+
+import "hash"
+
+// Predefined CRC-8 polynomials.
+const (
+	// CRC8SMBus is the polynomial x^8 + x^2 + x + 1 used by SMBus (CRC-8/SMBUS).
+	CRC8SMBus = 0x07
+	// CRC8Maxim is the polynomial x^8 + x^5 + x^4 + 1 used by Maxim/Dallas 1-Wire devices (CRC-8/MAXIM).
+	// The Maxim CRC is computed least significant bit first.
+	CRC8Maxim = 0x31
+)
+
+// hash8 is the common interface implemented by all 8-bit hash functions.
+type hash8 interface {
+	hash.Hash
+	// Sum8 returns the 8-bit checksum.
+	Sum8() uint8
+}
+
+// crc8Table is a 256-word table representing a CRC-8 polynomial for efficient processing.
+type crc8Table [256]uint8
+
+// makeCRC8Table returns a crc8Table for the polynomial poly.
+// If reflected is true, the table processes bits least significant first.
+func makeCRC8Table(poly uint8, reflected bool) *crc8Table {
+	var reversed uint8
+	for i := 0; i < 8; i++ {
+		if poly&(1<<i) != 0 {
+			reversed |= 0x80 >> i
+		}
+	}
+
+	t := new(crc8Table)
+	for i := range t {
+		crc := uint8(i)
+		for j := 0; j < 8; j++ {
+			switch {
+			case reflected && crc&0x01 != 0:
+				crc = crc>>1 ^ reversed
+			case reflected:
+				crc >>= 1
+			case crc&0x80 != 0:
+				crc = crc<<1 ^ poly
+			default:
+				crc <<= 1
+			}
+		}
+		t[i] = crc
+	}
+	return t
+}
+
+// crc8Digest represents the partial evaluation of a CRC-8 checksum.
+// The initial value and the final XOR value are zero.
+type crc8Digest struct {
+	crc uint8
+	tab *crc8Table
+}
+
+// newCRC8 returns a new hash8 computing the CRC-8 checksum using the table t.
+func newCRC8(t *crc8Table) hash8 {
+	return &crc8Digest{tab: t}
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *crc8Digest) Size() int {
+	return 1
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *crc8Digest) BlockSize() int {
+	return 1
+}
+
+// Reset resets the hash to its initial state.
+func (d *crc8Digest) Reset() {
+	d.crc = 0
+}
+
+// Write adds p to the running checksum. It never returns an error.
+func (d *crc8Digest) Write(p []byte) (int, error) {
+	crc := d.crc
+	for _, b := range p {
+		crc = d.tab[crc^b]
+	}
+	d.crc = crc
+	return len(p), nil
+}
+
+// Sum8 returns the 8-bit checksum.
+func (d *crc8Digest) Sum8() uint8 {
+	return d.crc
+}
+
+// Sum appends the checksum to b and returns the resulting slice.
+func (d *crc8Digest) Sum(b []byte) []byte {
+	return append(b, d.crc)
+}
+
+// newCRC8Hasher creates a new Hasher instance for the CRC-8 algorithm with the given polynomial.
+// The checksum is computed most significant bit first with an initial value of zero.
+func newCRC8Hasher(poly uint8) Hasher {
+	t := makeCRC8Table(poly, false)
+	return &hasher8{name: "crc8", HashFunc: func() hash8 { return newCRC8(t) }}
+}
+
+// crc8SMBusTable is the table of the CRC-8/SMBUS algorithm.
+var crc8SMBusTable = makeCRC8Table(CRC8SMBus, false)
+
+// newCRC8SMBusHasher creates a new Hasher instance for the CRC-8/SMBUS algorithm.
+func newCRC8SMBusHasher() Hasher {
+	return &hasher8{name: "crc8-smbus", HashFunc: func() hash8 { return newCRC8(crc8SMBusTable) }}
+}
+
+// crc8MaximTable is the table of the CRC-8/MAXIM algorithm.
+var crc8MaximTable = makeCRC8Table(CRC8Maxim, true)
+
+// newCRC8MaximHasher creates a new Hasher instance for the CRC-8/MAXIM algorithm.
+func newCRC8MaximHasher() Hasher {
+	return &hasher8{name: "crc8-maxim", HashFunc: func() hash8 { return newCRC8(crc8MaximTable) }}
+}
@@ -0,0 +1,235 @@
+package hasher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+)
+
+// inputHasher is a Hasher that transforms the input before passing it to the base Hasher.
+// Options that change what is hashed (rather than how) wrap the configured hasher with it,
+// so they compose with any algorithm option applied before them.
+type inputHasher struct {
+	base Hasher
+	// fromString transforms a string input. If nil, the string is passed through.
+	fromString func(string) (string, error)
+	// fromReader transforms an io.Reader input. If nil, the reader is passed through.
+	fromReader func(io.Reader) (io.Reader, error)
+}
+
+// Name returns the name of the base algorithm.
+func (i *inputHasher) Name() string {
+	return algorithmName(i.base)
+}
+
+// unwrap returns the base hasher.
+func (i *inputHasher) unwrap() Hasher {
+	return i.base
+}
+
+// size returns the length of the hashes of the base algorithm.
+func (i *inputHasher) size() int {
+	return digestSize(i.base)
+}
+
+// transformString returns the transformed string input.
+func (i *inputHasher) transformString(s string) (string, error) {
+	if i.fromString == nil {
+		return s, nil
+	}
+	return i.fromString(s)
+}
+
+// transformReader returns the transformed io.Reader input.
+func (i *inputHasher) transformReader(r io.Reader) (io.Reader, error) {
+	if i.fromReader == nil {
+		return r, nil
+	}
+	return i.fromReader(r)
+}
+
+// GenHashFromString generates a hash from the transformed string using the base hasher.
+func (i *inputHasher) GenHashFromString(s string) ([]byte, error) {
+	str, err := i.transformString(s)
+	if err != nil {
+		return nil, err
+	}
+	return i.base.GenHashFromString(str)
+}
+
+// GenHashFromIOReader generates a hash from the transformed io.Reader using the base hasher.
+func (i *inputHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	rd, err := i.transformReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return i.base.GenHashFromIOReader(rd)
+}
+
+// CmpHashAndString compares a hash and the transformed string using the base hasher.
+func (i *inputHasher) CmpHashAndString(hash []byte, s string) error {
+	str, err := i.transformString(s)
+	if err != nil {
+		return err
+	}
+	return i.base.CmpHashAndString(hash, str)
+}
+
+// CmpHashAndIOReader compares a hash and the transformed io.Reader using the base hasher.
+func (i *inputHasher) CmpHashAndIOReader(hash []byte, r io.Reader) error {
+	rd, err := i.transformReader(r)
+	if err != nil {
+		return err
+	}
+	return i.base.CmpHashAndIOReader(hash, rd)
+}
+
+// genHashFromImage generates a hash from a decoded image using the base hasher. The transformations
+// apply to the encoded input, so the image is passed through.
+func (i *inputHasher) genHashFromImage(img image.Image) ([]byte, error) {
+	return hashImage(i.base, img)
+}
+
+// newPrefixHasher returns an inputHasher that hashes the bytes returned by prefix
+// followed by the input. prefix is called once for every hash generation or comparison.
+func newPrefixHasher(base Hasher, prefix func() []byte) *inputHasher {
+	return &inputHasher{
+		base: base,
+		fromString: func(s string) (string, error) {
+			return string(prefix()) + s, nil
+		},
+		fromReader: func(r io.Reader) (io.Reader, error) {
+			return io.MultiReader(bytes.NewReader(prefix()), r), nil
+		},
+	}
+}
+
+// compactSize returns the Bitcoin CompactSize encoding of n.
+//   - n < 0xfd: n as a single byte.
+//   - n <= 0xffff: 0xfd followed by n as a little-endian uint16.
+//   - n <= 0xffffffff: 0xfe followed by n as a little-endian uint32.
+//   - otherwise: 0xff followed by n as a little-endian uint64.
+func compactSize(n uint64) []byte {
+	switch {
+	case n < 0xfd:
+		return []byte{byte(n)}
+	case n <= 0xffff:
+		b := make([]byte, 3)
+		b[0] = 0xfd
+		binary.LittleEndian.PutUint16(b[1:], uint16(n))
+		return b
+	case n <= 0xffffffff:
+		b := make([]byte, 5)
+		b[0] = 0xfe
+		binary.LittleEndian.PutUint32(b[1:], uint32(n))
+		return b
+	default:
+		b := make([]byte, 9)
+		b[0] = 0xff
+		binary.LittleEndian.PutUint64(b[1:], n)
+		return b
+	}
+}
+
+// newLengthPrefixHasher returns an inputHasher that hashes the length of the input, encoded by encode,
+// followed by the input. The length of an io.Reader is not known in advance, so the reader is read into memory first.
+func newLengthPrefixHasher(base Hasher, encode func(n uint64) []byte) *inputHasher {
+	return &inputHasher{
+		base: base,
+		fromString: func(s string) (string, error) {
+			return string(encode(uint64(len(s)))) + s, nil
+		},
+		fromReader: func(r io.Reader) (io.Reader, error) {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return io.MultiReader(bytes.NewReader(encode(uint64(len(b)))), bytes.NewReader(b)), nil
+		},
+	}
+}
+
+// uint64BigEndian returns n as an 8-byte big-endian integer.
+func uint64BigEndian(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
+}
+
+// hexDecodeReader returns a reader that hex-decodes r. ASCII whitespace (such as line breaks
+// in a hex dump) is skipped. Malformed hex is reported as an error wrapping the decode error.
+func hexDecodeReader(r io.Reader) (io.Reader, error) {
+	return &hexDecodeErrorReader{r: hex.NewDecoder(&skipSpaceReader{r: r})}, nil
+}
+
+// skipSpaceReader is an io.Reader that drops ASCII whitespace from the underlying reader.
+type skipSpaceReader struct {
+	r io.Reader
+}
+
+// Read reads from the underlying reader and removes ASCII whitespace from p.
+func (s *skipSpaceReader) Read(p []byte) (int, error) {
+	for {
+		n, err := s.r.Read(p)
+		j := 0
+		for _, c := range p[:n] {
+			switch c {
+			case ' ', '\t', '\n', '\v', '\f', '\r':
+				continue
+			}
+			p[j] = c
+			j++
+		}
+		if j > 0 || err != nil || n == 0 {
+			return j, err
+		}
+	}
+}
+
+// hexDecodeErrorReader is an io.Reader that wraps errors returned by a hex decoder.
+type hexDecodeErrorReader struct {
+	r io.Reader
+}
+
+// Read reads from the hex decoder, wrapping any error other than io.EOF.
+func (h *hexDecodeErrorReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return n, fmt.Errorf("failed to decode hex input: %w", err)
+	}
+	return n, err
+}
+
+// failingHasher is a Hasher that always returns err. It is used when an option is given an invalid
+// argument, so that the error surfaces from Generate and Compare instead of being lost.
+type failingHasher struct {
+	name string
+	err  error
+}
+
+// Name returns the name of the algorithm.
+func (f *failingHasher) Name() string {
+	return f.name
+}
+
+// GenHashFromString returns the error.
+func (f *failingHasher) GenHashFromString(_ string) ([]byte, error) {
+	return nil, f.err
+}
+
+// GenHashFromIOReader returns the error.
+func (f *failingHasher) GenHashFromIOReader(_ io.Reader) ([]byte, error) {
+	return nil, f.err
+}
+
+// CmpHashAndString returns the error.
+func (f *failingHasher) CmpHashAndString(_ []byte, _ string) error {
+	return f.err
+}
+
+// CmpHashAndIOReader returns the error.
+func (f *failingHasher) CmpHashAndIOReader(_ []byte, _ io.Reader) error {
+	return f.err
+}
@@ -0,0 +1,53 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// Digest is a hash value generated by GenerateDigest. It provides methods to encode the hash.
+type Digest []byte
+
+// GenerateDigest generates a hash from the input and returns it as a Digest.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateDigest(input any) (Digest, error) {
+	b, err := h.Generate(input)
+	if err != nil {
+		return nil, err
+	}
+	return Digest(b), nil
+}
+
+// Bytes returns the digest as a byte slice.
+func (d Digest) Bytes() []byte {
+	return []byte(d)
+}
+
+// Hex returns the digest encoded as a lowercase hex string.
+func (d Digest) Hex() string {
+	return hex.EncodeToString(d)
+}
+
+// String returns the digest encoded as a lowercase hex string, so a Digest prints as hex with fmt.
+// Because fmt applies %x to the result of String, use Hex or %v rather than %x to print a Digest.
+func (d Digest) String() string {
+	return d.Hex()
+}
+
+// Base64 returns the digest encoded with standard, padded base64 (RFC 4648).
+func (d Digest) Base64() string {
+	return base64.StdEncoding.EncodeToString(d)
+}
+
+// Base32 returns the digest encoded with standard, padded base32 (RFC 4648).
+func (d Digest) Base32() string {
+	return base32.StdEncoding.EncodeToString(d)
+}
+
+// Equal reports whether d and other are the same digest.
+// The comparison takes constant time for digests of the same length.
+func (d Digest) Equal(other Digest) bool {
+	return subtle.ConstantTimeCompare(d, other) == 1
+}
@@ -0,0 +1,195 @@
+package hasher
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Encoding is the text encoding of the hashes returned by GenerateString. See WithEncoding.
+type Encoding int
+
+const (
+	// EncodingHex encodes a hash as a lowercase hex string. It is the default.
+	EncodingHex Encoding = iota
+	// EncodingBase64 encodes a hash with standard, padded base64 (base64.StdEncoding).
+	EncodingBase64
+	// EncodingBase64URL encodes a hash with unpadded, URL-safe base64 (base64.RawURLEncoding).
+	EncodingBase64URL
+	// EncodingRaw returns the hash bytes as-is, converted to a string.
+	EncodingRaw
+)
+
+// GenerateString generates a hash from the input and encodes it with the encoding set by WithEncoding,
+// or as a lowercase hex string if no encoding is set.
+// If the encoding is not one of the Encoding constants, ErrUnsupportedEncoding is returned before the input is read.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateString(input any) (string, error) {
+	var encode func([]byte) string
+	switch h.encoding {
+	case EncodingHex:
+		encode = hex.EncodeToString
+	case EncodingBase64:
+		encode = base64.StdEncoding.EncodeToString
+	case EncodingBase64URL:
+		encode = base64.RawURLEncoding.EncodeToString
+	case EncodingRaw:
+		encode = func(b []byte) string { return string(b) }
+	default:
+		return "", fmt.Errorf("%w: %d", ErrUnsupportedEncoding, h.encoding)
+	}
+
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+	return encode(digest), nil
+}
+
+// GenerateHex generates a hash from the input and encodes it as a lowercase hex string,
+// e.g. "098f6bcd4621d373cade4e832627b4f6" for the MD5 of "test".
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateHex(input any) (string, error) {
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(digest), nil
+}
+
+// CompareHex compares a hash given as a hex string and the input, as Compare does.
+// Surrounding whitespace is trimmed and upper case letters are accepted, so a hash copied from a web page,
+// e.g. "  098F6BCD4621D373CADE4E832627B4F6 ", can be passed as-is.
+// If hexStr is not a valid hex string, ErrInvalidHexDigest is returned.
+func (h *Hash) CompareHex(hexStr string, input any) error {
+	hash, err := hex.DecodeString(strings.ToLower(strings.TrimSpace(hexStr)))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHexDigest, err)
+	}
+	return h.Compare(hash, input)
+}
+
+// GenerateBase64 generates a hash from the input and encodes it with standard, padded base64
+// (base64.StdEncoding), e.g. "CY9rzUYh03PK3k6DJie09g==" for the MD5 of "test".
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateBase64(input any) (string, error) {
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(digest), nil
+}
+
+// GenerateBase64URL generates a hash from the input and encodes it with unpadded, URL-safe base64
+// (base64.RawURLEncoding), so it can be embedded in URLs and file names without escaping.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateBase64URL(input any) (string, error) {
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(digest), nil
+}
+
+// GenerateTo generates a hash from the input and writes it to w in the given encoding:
+// "hex" (lowercase), "base64" (standard, padded), or "raw" (the digest bytes as-is).
+// The digest is encoded while it is written, without building an intermediate string.
+// If the encoding is not one of them, ErrUnsupportedEncoding is returned before the input is read.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateTo(w io.Writer, input any, encoding string) error {
+	var enc io.WriteCloser
+	switch encoding {
+	case "hex":
+		enc = nopWriteCloser{hex.NewEncoder(w)}
+	case "base64":
+		enc = base64.NewEncoder(base64.StdEncoding, w)
+	case "raw":
+		enc = nopWriteCloser{w}
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+
+	digest, err := h.Generate(input)
+	if err != nil {
+		return err
+	}
+
+	if _, err := enc.Write(digest); err != nil {
+		return err
+	}
+	// Close flushes the final partial block of base64.
+	return enc.Close()
+}
+
+// nopWriteCloser is an io.WriteCloser whose Close does nothing.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// GenerateBase36 generates a hash from the input and encodes it as an uppercase base36 string.
+// The digest is read as a big-endian unsigned integer. The string is left-padded with "0" to the
+// length needed for the largest digest of the same size, so its length depends only on the
+// algorithm and leading zero bytes are preserved. Uppercase base36 only uses characters of the
+// QR code alphanumeric mode, so it is compact in QR codes.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateBase36(input any) (string, error) {
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+	return encodeBase36(digest), nil
+}
+
+// encodeBase36 encodes b as a zero-padded, uppercase base36 string.
+func encodeBase36(b []byte) string {
+	s := strings.ToUpper(new(big.Int).SetBytes(b).Text(36))
+	width := len(maxBase36(len(b)))
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// maxBase36 returns the base36 encoding of the largest n-byte unsigned integer.
+func maxBase36(n int) string {
+	m := new(big.Int).Lsh(big.NewInt(1), uint(n*8))
+	return m.Sub(m, big.NewInt(1)).Text(36)
+}
+
+// GenerateGroupedHex generates a hash from the input and encodes it as a lowercase hex string,
+// with sep inserted every groupSize characters, e.g. "a94a 8fe5 ccb1 ..." for groupSize 4 and sep " ".
+// Grouped hex is easier for humans to compare, like GPG fingerprints.
+// If groupSize is not positive, ErrInvalidGroupSize is returned.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+func (h *Hash) GenerateGroupedHex(input any, groupSize int, sep string) (string, error) {
+	if groupSize <= 0 {
+		return "", fmt.Errorf("%w: %d", ErrInvalidGroupSize, groupSize)
+	}
+
+	digest, err := h.Generate(input)
+	if err != nil {
+		return "", err
+	}
+
+	s := hex.EncodeToString(digest)
+	var b strings.Builder
+	for i := 0; i < len(s); i += groupSize {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		end := i + groupSize
+		if end > len(s) {
+			end = len(s)
+		}
+		b.WriteString(s[i:end])
+	}
+	return b.String(), nil
+}
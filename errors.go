@@ -9,4 +9,50 @@ var (
 	ErrHashMismatch = errors.New("hash mismatch")
 	// ErrPhashNotSupportedString is an error that is returned when phash does not support string input.
 	ErrPhashNotSupportedString = errors.New("phash does not support string input")
+	// ErrInvalidImage is an error that is returned when the input for perceptual hashing cannot be decoded as an image.
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrStreamingNotSupported is an error that is returned when the algorithm does not support streaming (io.Reader) input.
+	ErrStreamingNotSupported = errors.New("algorithm does not support streaming input")
+	// ErrUnknownAlgorithm is an error that is returned when no algorithm is registered under the given name.
+	ErrUnknownAlgorithm = errors.New("unknown algorithm")
+	// ErrAlgorithmAlreadyRegistered is an error that is returned when an algorithm is already registered under the given name.
+	ErrAlgorithmAlreadyRegistered = errors.New("algorithm already registered")
+	// ErrInvalidAlgorithm is an error that is returned when an algorithm cannot be registered because its name or factory is empty.
+	ErrInvalidAlgorithm = errors.New("invalid algorithm")
+	// ErrStateNotSupported is an error that is returned when the algorithm cannot save and restore its hash state.
+	ErrStateNotSupported = errors.New("algorithm does not support saving hash state")
+	// ErrInvalidCheckpoint is an error that is returned when a checkpoint file cannot be restored.
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+	// ErrInvalidGroupSize is an error that is returned when the group size for grouped hex output is not positive.
+	ErrInvalidGroupSize = errors.New("group size must be positive")
+	// ErrInvalidKeyLength is an error that is returned when the key of a keyed algorithm is too long.
+	ErrInvalidKeyLength = errors.New("invalid key length")
+	// ErrUnsupportedEncoding is an error that is returned when the encoding of a digest is not supported.
+	ErrUnsupportedEncoding = errors.New("unsupported encoding")
+	// ErrInvalidHashLength is an error that is returned when the hash to compare does not have the length of the algorithm's hashes.
+	ErrInvalidHashLength = errors.New("invalid hash length")
+	// ErrInvalidOutputLength is an error that is returned when the output length of an extendable-output function is not positive.
+	ErrInvalidOutputLength = errors.New("output length must be positive")
+	// ErrInvalidHexDigest is an error that is returned when a hash given as a hex string cannot be decoded.
+	ErrInvalidHexDigest = errors.New("invalid hex digest")
+	// ErrInvalidChunkSize is an error that is returned when the chunk sizes of a Chunker are not in order or not positive.
+	ErrInvalidChunkSize = errors.New("invalid chunk size")
+	// ErrInvalidLeafSize is an error that is returned when the leaf size of a tree hash is not positive.
+	ErrInvalidLeafSize = errors.New("leaf size must be positive")
+	// ErrInvalidTruncation is an error that is returned when the length of a truncated hash is not positive or exceeds the length of the full hash.
+	ErrInvalidTruncation = errors.New("invalid truncation length")
+	// ErrNotReproducible is an error that is returned when a hash would have to be generated again to be returned,
+	// but the algorithm embeds a random salt in every hash, e.g. bcrypt.
+	ErrNotReproducible = errors.New("algorithm does not generate reproducible hashes")
+	// ErrNotPerceptual is an error that is returned when an operation that measures the similarity of hashes
+	// is used with an algorithm that is not a perceptual hash.
+	ErrNotPerceptual = errors.New("algorithm is not a perceptual hash")
+	// ErrNilReader is an error that is returned when a ReaderFunc returns a nil io.Reader without an error.
+	ErrNilReader = errors.New("ReaderFunc returned nil reader")
+	// ErrImageTooLarge is an error that is returned when an image exceeds the size set by WithPhashMaxDimension.
+	ErrImageTooLarge = errors.New("image too large")
+	// ErrInvalidOption is an error that is returned when an option does not apply to the configured algorithm.
+	ErrInvalidOption = errors.New("invalid option")
+	// ErrInvalidTimeBucket is an error that is returned when the duration of a time bucket is not positive.
+	ErrInvalidTimeBucket = errors.New("time bucket must be positive")
 )
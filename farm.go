@@ -0,0 +1,19 @@
+package hasher
+
+import (
+	"encoding/binary"
+
+	farm "github.com/dgryski/go-farm"
+)
+
+// newFarmHash64Hasher creates a new Hasher instance for FarmHash Fingerprint64.
+// Unlike farm.Hash64, Fingerprint64 is guaranteed not to change between versions, so stored hashes stay valid.
+// The hash is encoded in big-endian order.
+func newFarmHash64Hasher() Hasher {
+	return &hasher{
+		name: "farmhash64",
+		HashFunc: newOneShotHash(8, func(b []byte) []byte {
+			return binary.BigEndian.AppendUint64(nil, farm.Fingerprint64(b))
+		}),
+	}
+}
@@ -0,0 +1,31 @@
+package hasher
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// openFile returns a ReaderFunc that opens the file at path.
+func openFile(path string) ReaderFunc {
+	return func() (io.Reader, error) {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		return f, nil
+	}
+}
+
+// GenerateFile generates a hash from the content of the file at path.
+// The file is opened, hashed as an io.Reader, and closed. With WithRetry, the file is reopened for every attempt.
+func (h *Hash) GenerateFile(path string) ([]byte, error) {
+	return h.Generate(openFile(path))
+}
+
+// CompareFile compares hash and the content of the file at path.
+// The file is opened, compared as an io.Reader, and closed. If they are different, ErrHashMismatch is returned.
+func (h *Hash) CompareFile(hash []byte, path string) error {
+	return h.Compare(hash, openFile(path))
+}
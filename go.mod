@@ -4,9 +4,15 @@ go 1.20
 
 require (
 	github.com/azr/phash v0.2.0
-	github.com/cespare/xxhash v1.1.0
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/dgryski/go-farm v0.0.0-20240924180020-3414d57e47da
+	github.com/dgryski/go-metro v0.0.0-20250106013310-edb8663e5e33
 	github.com/jzelinskie/whirlpool v0.0.0-20201016144138-0675e54bb004
+	github.com/minio/highwayhash v1.0.4
 	github.com/reusee/mmh3 v0.0.0-20140820141314-64b85163255b
+	github.com/zeebo/xxh3 v1.0.2
+	golang.org/x/crypto v0.31.0
+	golang.org/x/image v0.18.0
 	lukechampine.com/blake3 v1.3.0
 )
 
@@ -14,5 +20,5 @@ require (
 	github.com/azr/gift v1.1.2 // indirect
 	github.com/disintegration/imaging v1.6.2 // indirect
 	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
-	golang.org/x/image v0.18.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
 )
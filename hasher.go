@@ -51,14 +51,55 @@ package hasher
 
 import (
 	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"hash"
+	"image"
 	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Hash is a struct that contains the methods to generate and compare hashes.
+//
+// A Hash is not modified after NewHash returns, and the built-in algorithms allocate their state per call,
+// so a single *Hash can be used by multiple goroutines at the same time. A user-defined Hasher set by
+// WithUserDifinedAlgorithm is shared by those goroutines and must be safe for concurrent use itself.
+// With WithCheckpoint, concurrent calls write the same checkpoint file, so use a Hash per goroutine instead.
 type Hash struct {
+	hashConfig
+	// hashPool holds reusable hash.Hash instances for GenerateReused. It is created on the first GenerateReused,
+	// so a Hash that never calls it does not pay for the pool.
+	hashPool atomic.Pointer[sync.Pool]
+	// size is the length of the hashes of the algorithm in bytes plus one, or 0 if it is not computed yet.
+	// It is computed on first use because some algorithms only know it from a hash.Hash instance.
+	size atomic.Int64
+}
+
+// hashConfig is the configuration of a Hash set by the options. It is copied by Clone.
+type hashConfig struct {
 	hasher Hasher
+	// bufferSize is the size of the buffer used to copy an io.Reader into the hash.
+	// If it is zero, defaultBufferSize is used.
+	bufferSize int
+	// progressBar renders the progress of hashing an io.Reader. If it is nil, no progress is rendered.
+	progressBar *progressBar
+	// retries is the number of times hashing an io.Reader is retried after a read error.
+	retries int
+	// checkpoint saves the hash state periodically while hashing an io.Reader. If it is nil, no checkpoint is saved.
+	checkpoint *checkpoint
+	// observer is called after every Generate with the algorithm, the number of bytes hashed, and the duration.
+	observer func(algorithm string, bytes int64, d time.Duration)
+	// timeout is the maximum duration of hashing an io.Reader in Generate. If it is zero, there is no limit.
+	timeout time.Duration
+	// concurrency is the number of goroutines GenerateBatch and TreeHashAt hash with. If it is zero, GenerateBatch
+	// hashes the inputs in turn and TreeHashAt uses runtime.GOMAXPROCS(0) goroutines.
+	concurrency int
+	// encoding is the text encoding of the hashes returned by GenerateString.
+	encoding Encoding
 }
 
 // NewHash returns a new Hasher struct. Default hash algorithm is MD5SUM.
@@ -66,7 +107,7 @@ type Hash struct {
 // e.g. NewHash(WithSha1Algorithm())
 func NewHash(opts ...Option) *Hash {
 	h := &Hash{
-		hasher: &md5sumHasher{},
+		hashConfig: hashConfig{hasher: &md5sumHasher{}},
 	}
 
 	for _, opt := range opts {
@@ -75,40 +116,393 @@ func NewHash(opts ...Option) *Hash {
 	return h
 }
 
+// Clone returns a new Hash with the same configuration as h, without applying the options again.
+// The clone shares the configured Hasher with h. The built-in hashers are stateless (each call allocates
+// its own hash.Hash), so h and the clone can be used from different goroutines. A user-defined Hasher
+// is shared as-is, so it must be safe for concurrent use if h and the clone are used concurrently.
+func (h *Hash) Clone() *Hash {
+	return &Hash{hashConfig: h.hashConfig}
+}
+
+// internal returns a copy of h for hashing the parts of an operation made of several hashes, such as the
+// leaves of TreeHash. The copy has no observer, so the operation is reported once instead of once per part,
+// and no checkpoint, because a checkpoint of one part cannot resume the operation.
+func (h *Hash) internal() *Hash {
+	c := h.Clone()
+	c.observer = nil
+	c.checkpoint = nil
+	return c
+}
+
+// Algorithm returns the name of the configured algorithm, e.g. "sha256" for NewHash(WithSha256()).
+// The names of the built-in algorithms are the Algorithm constants. If the algorithm is a user-defined
+// Hasher that does not implement Named, "user-defined" is returned. Options that wrap the algorithm,
+// such as WithTrimSpace, keep its name.
+func (h *Hash) Algorithm() string {
+	return algorithmName(h.hasher)
+}
+
 // Generate generates a hash from the input.
-// The input can be a string or an io.Reader. If the input is not a string or an io.Reader,
-// ErrUnsupportedInputType is returned.
+// The input can be a string, a []byte, an io.Reader, or a ReaderFunc. A []byte is hashed as its content:
+// directly if the algorithm implements BytesHasher, otherwise like an io.Reader over it, e.g. the bytes of
+// an image can be hashed with perceptual hash.
+// Perceptual hash also accepts a decoded image.Image, which is hashed without decoding it again, including
+// when it is wrapped by an option such as WithTruncate; other algorithms return ErrUnsupportedInputType for
+// an image.Image.
+// Any other type, including a fmt.Stringer that is not an io.Reader, returns ErrUnsupportedInputType.
+// A value that implements io.Reader, such as *os.File, *bytes.Buffer, or *strings.Reader, is always read,
+// even if it also implements fmt.Stringer.
+// Errors are prefixed with the name of the algorithm (see Algorithm), e.g. "phash: phash does not support
+// string input"; use errors.Is to match the sentinel errors such as ErrPhashNotSupportedString.
 func (h *Hash) Generate(input any) ([]byte, error) {
+	if h.timeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		defer cancel()
+		return h.GenerateContext(ctx, input)
+	}
+	return h.generate(input, h.genHashFromIOReader)
+}
+
+// generate generates a hash from the input, hashing io.Reader input with fn,
+// and reports it to the observer if one is configured.
+func (h *Hash) generate(input any, fn func(io.Reader) ([]byte, error)) ([]byte, error) {
+	input = h.prepareInput(input)
+	var (
+		digest []byte
+		err    error
+	)
+	if h.observer != nil {
+		digest, err = h.observe(input, fn)
+	} else {
+		digest, err = h.hashInput(input, fn)
+	}
+	if err != nil {
+		return nil, h.wrapError(err)
+	}
+	return digest, nil
+}
+
+// wrapError adds the name of the algorithm to err, e.g. "phash: phash does not support string input".
+// The sentinel errors can still be matched with errors.Is.
+func (h *Hash) wrapError(err error) error {
+	return fmt.Errorf("%s: %w", h.Algorithm(), err)
+}
+
+// hashInput generates a hash from the input, hashing io.Reader input with fn.
+func (h *Hash) hashInput(input any, fn func(io.Reader) ([]byte, error)) ([]byte, error) {
 	switch v := input.(type) {
 	case string:
 		return h.hasher.GenHashFromString(v)
+	case []byte:
+		return h.hasher.(BytesHasher).GenHashFromBytes(v)
 	case io.Reader:
-		return h.hasher.GenHashFromIOReader(v)
+		return h.readWithRetry(v, nil, fn)
+	case ReaderFunc:
+		return h.readWithRetry(nil, v, fn)
+	case image.Image:
+		return h.genHashFromImage(v)
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnsupportedInputType, v)
 	}
 }
 
+// genHashFromImage generates a hash from a decoded image.
+// If the algorithm cannot hash an image.Image, ErrUnsupportedInputType is returned.
+func (h *Hash) genHashFromImage(img image.Image) ([]byte, error) {
+	return hashImage(h.hasher, img)
+}
+
 // Compare compares hash and input.
-// The input can be a string or an io.Reader. If the input is not a string or an io.Reader, ErrUnsupportedInputType is returned.
+// The input can be a string, a []byte, an io.Reader, a ReaderFunc, or an image.Image, as for Generate.
+// If the input is not one of them, ErrUnsupportedInputType is returned.
 // If the hash and the input are the same, nil is returned.
 // If the hash and the input are different with hasher support algorithm, an ErrHashMismatch is returned.
+// The built-in algorithms compare hashes in constant time, so Compare does not leak through timing
+// how many leading bytes of the hash matched.
+// Errors other than ErrInvalidHashLength are prefixed with the name of the algorithm, as for Generate.
+// If the algorithm generates hashes of a fixed length and the length of hash differs, ErrInvalidHashLength
+// is returned without reading the input. The length of user-defined and bcrypt hashes is not checked.
 func (h *Hash) Compare(hash []byte, input any) error {
-	switch v := input.(type) {
+	if err := h.checkHashLength(hash); err != nil {
+		return err
+	}
+	if err := h.compare(hash, input); err != nil {
+		return h.wrapError(err)
+	}
+	return nil
+}
+
+// checkHashLength returns ErrInvalidHashLength if the algorithm generates hashes of a fixed length
+// and the length of hash differs.
+func (h *Hash) checkHashLength(hash []byte) error {
+	if size := h.digestSize(); size > 0 && len(hash) != size {
+		return fmt.Errorf("%w: got %d bytes, want %d bytes", ErrInvalidHashLength, len(hash), size)
+	}
+	return nil
+}
+
+// digestSize returns the length of the hashes of the algorithm in bytes, or 0 if it is unknown or not fixed.
+// The length is computed on the first call and cached.
+func (h *Hash) digestSize() int {
+	if size := h.size.Load(); size > 0 {
+		return int(size - 1)
+	}
+	size := digestSize(h.hasher)
+	h.size.Store(int64(size) + 1)
+	return size
+}
+
+// compare compares hash and input with the configured algorithm.
+func (h *Hash) compare(hash []byte, input any) error {
+	switch v := h.prepareInput(input).(type) {
 	case string:
 		return h.hasher.CmpHashAndString(hash, v)
+	case []byte:
+		return h.hasher.(BytesHasher).CmpHashAndBytes(hash, v)
 	case io.Reader:
-		return h.hasher.CmpHashAndIOReader(hash, v)
+		_, err := h.readWithRetry(v, nil, func(r io.Reader) ([]byte, error) {
+			return nil, h.cmpHashAndIOReader(hash, r)
+		})
+		return err
+	case ReaderFunc:
+		_, err := h.readWithRetry(nil, v, func(r io.Reader) ([]byte, error) {
+			return nil, h.cmpHashAndIOReader(hash, r)
+		})
+		return err
+	case image.Image:
+		computed, err := h.genHashFromImage(v)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare(hash, computed) != 1 {
+			return ErrHashMismatch
+		}
+		return nil
 	default:
 		return fmt.Errorf("%w: %T", ErrUnsupportedInputType, v)
 	}
 }
 
+// CompareReturning compares the expected hash and input, and returns the hash computed from the input.
+// The computed hash is returned both on match and on mismatch, so it can be logged without calling Generate again.
+// If the hashes are different, ErrHashMismatch is returned with the computed hash.
+// If the input cannot be hashed, the computed hash is nil. As with Compare, ErrInvalidHashLength is
+// returned without reading the input if the length of expected is wrong, and errors are prefixed
+// with the name of the algorithm.
+// The match is checked by the algorithm as Compare does. An algorithm that embeds a random salt in every
+// hash, such as bcrypt, has no computed hash to return, so ErrNotReproducible is returned without reading
+// the input; use Compare instead. If the algorithm is not backed by a hash.Hash, an io.Reader is read into memory.
+func (h *Hash) CompareReturning(expected []byte, input any) (computed []byte, err error) {
+	if err := h.checkHashLength(expected); err != nil {
+		return nil, err
+	}
+	if isRandomized(h.hasher) {
+		return nil, h.wrapError(fmt.Errorf("%w: use Compare to check the input", ErrNotReproducible))
+	}
+
+	_, streamable := h.hasher.(streamer)
+	if !streamable {
+		if input, err = h.bufferInput(input); err != nil {
+			return nil, h.wrapError(err)
+		}
+	}
+	computed, err = h.Generate(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmpErr error
+	if streamable {
+		if subtle.ConstantTimeCompare(expected, computed) != 1 {
+			cmpErr = ErrHashMismatch
+		}
+	} else {
+		cmpErr = h.compare(expected, input)
+	}
+	switch {
+	case cmpErr == nil:
+		return computed, nil
+	case errors.Is(cmpErr, ErrHashMismatch):
+		return computed, h.wrapError(cmpErr)
+	default:
+		return nil, h.wrapError(cmpErr)
+	}
+}
+
+// BufferAndHash reads all of r into memory while hashing it, and returns a seekable view of
+// the content together with the hash. It is useful when the content must be both hashed and
+// forwarded, e.g. an HTTP request body. The returned io.ReadSeeker is positioned at the start.
+func (h *Hash) BufferAndHash(r io.Reader) (io.ReadSeeker, []byte, error) {
+	var buf bytes.Buffer
+	digest, err := h.Generate(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, nil, err
+	}
+	return bytes.NewReader(buf.Bytes()), digest, nil
+}
+
+// prepareInput returns the input in the form that Generate and Compare hash.
+// A []byte is kept as-is if the algorithm implements BytesHasher and no option needs to read it
+// as an io.Reader; otherwise, the input is converted by normalizeInput.
+func (h *Hash) prepareInput(input any) any {
+	if _, ok := input.([]byte); ok && h.progressBar == nil && h.checkpoint == nil {
+		if _, ok := h.hasher.(BytesHasher); ok {
+			return input
+		}
+	}
+	return normalizeInput(input)
+}
+
+// normalizeInput converts the input types that are hashed as their content into an io.Reader.
+// A []byte becomes a *bytes.Reader; other inputs are returned as-is.
+func normalizeInput(input any) any {
+	if b, ok := input.([]byte); ok {
+		return bytes.NewReader(b)
+	}
+	return input
+}
+
+// bufferInput returns input with an io.Reader or a ReaderFunc read into a []byte, so that it can be
+// hashed more than once. Reading honors WithRetry and WithTimeout as Generate does. Other inputs are returned as-is.
+func (h *Hash) bufferInput(input any) (any, error) {
+	readAll := io.ReadAll
+	if h.timeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		defer cancel()
+		readAll = func(r io.Reader) ([]byte, error) {
+			return io.ReadAll(&contextReader{ctx: ctx, r: r})
+		}
+	}
+
+	switch v := input.(type) {
+	case io.Reader:
+		return h.readWithRetry(v, nil, readAll)
+	case ReaderFunc:
+		return h.readWithRetry(nil, v, readAll)
+	default:
+		return input, nil
+	}
+}
+
+// prefixInput returns an input that yields prefix followed by the content of input.
+// The input can be a string, a []byte, an io.Reader, or a ReaderFunc. The result is hashed through the same
+// path as the input: a ReaderFunc stays a ReaderFunc, and a seekable io.Reader becomes a ReaderFunc that
+// rewinds it, so WithRetry can still retry reading it.
+func prefixInput(prefix []byte, input any) (any, error) {
+	switch v := input.(type) {
+	case string:
+		return string(prefix) + v, nil
+	case []byte:
+		return append(append(make([]byte, 0, len(prefix)+len(v)), prefix...), v...), nil
+	case ReaderFunc:
+		return ReaderFunc(func() (io.Reader, error) {
+			r, err := v()
+			if err != nil || r == nil {
+				// readWithRetry reports a nil reader.
+				return nil, err
+			}
+			return prefixReader(prefix, r), nil
+		}), nil
+	case io.Reader:
+		s, ok := v.(io.Seeker)
+		if !ok {
+			return io.MultiReader(bytes.NewReader(prefix), v), nil
+		}
+		start, err := s.Seek(0, io.SeekCurrent)
+		if err != nil {
+			// The reader claims to be seekable but is not, e.g. a pipe.
+			return io.MultiReader(bytes.NewReader(prefix), v), nil
+		}
+		return ReaderFunc(func() (io.Reader, error) {
+			if _, err := s.Seek(start, io.SeekStart); err != nil {
+				return nil, err
+			}
+			return io.MultiReader(bytes.NewReader(prefix), v), nil
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedInputType, v)
+	}
+}
+
+// prefixReader returns a reader that yields prefix followed by r. If r is an io.Closer, so is the result.
+func prefixReader(prefix []byte, r io.Reader) io.Reader {
+	mr := io.MultiReader(bytes.NewReader(prefix), r)
+	if c, ok := r.(io.Closer); ok {
+		return struct {
+			io.Reader
+			io.Closer
+		}{mr, c}
+	}
+	return mr
+}
+
+// wrapReader wraps r with the readers required by the configured options.
+// If no option needs to observe the reader, r is returned as-is so that io.Copy
+// can still use its io.WriterTo fast path.
+func (h *Hash) wrapReader(r io.Reader) io.Reader {
+	if h.progressBar != nil {
+		r = h.progressBar.reader(r)
+	}
+	return r
+}
+
+// genHashFromIOReader generates a hash from an io.Reader.
+// If the buffer size is configured and the hasher is backed by a hash.Hash,
+// the reader is copied into the hash with a pooled buffer of that size.
+func (h *Hash) genHashFromIOReader(r io.Reader) ([]byte, error) {
+	r = h.wrapReader(r)
+	if h.checkpoint != nil {
+		return h.genHashWithCheckpoint(r)
+	}
+	if s, ok := h.hasher.(streamer); ok && h.bufferSize > 0 {
+		return h.stream(s, r)
+	}
+	return h.hasher.GenHashFromIOReader(r)
+}
+
+// cmpHashAndIOReader compares a hash and an io.Reader.
+// It honors the configured buffer size and checkpoints in the same way as genHashFromIOReader.
+func (h *Hash) cmpHashAndIOReader(hash []byte, r io.Reader) error {
+	if _, ok := h.hasher.(streamer); h.checkpoint == nil && (!ok || h.bufferSize <= 0) {
+		return h.hasher.CmpHashAndIOReader(hash, h.wrapReader(r))
+	}
+
+	got, err := h.genHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(hash, got) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// stream copies r into a new hash.Hash of s using a pooled buffer of the configured size,
+// and returns the hash.
+func (h *Hash) stream(s streamer, r io.Reader) ([]byte, error) {
+	hs := s.newHash()
+	if _, err := copyBuffer(hs, r, h.bufferSize); err != nil {
+		return nil, err
+	}
+	return hs.Sum(nil), nil
+}
+
 // hasher represents a generic hasher for implementing hash.Hash interface.
 type hasher struct {
+	name     string
 	HashFunc func() hash.Hash
 }
 
+// Name returns the name of the algorithm.
+func (s *hasher) Name() string {
+	return s.name
+}
+
+// newHash returns a new hash.Hash using the specified hash function.
+func (s *hasher) newHash() hash.Hash {
+	return s.HashFunc()
+}
+
 // GenHashFromString generates a hash from a string using the specified hash function.
 func (s *hasher) GenHashFromString(str string) ([]byte, error) {
 	h := s.HashFunc()
@@ -118,10 +512,32 @@ func (s *hasher) GenHashFromString(str string) ([]byte, error) {
 	return h.Sum(nil), nil
 }
 
+// GenHashFromBytes generates a hash from a byte slice using the specified hash function.
+func (s *hasher) GenHashFromBytes(b []byte) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := h.Write(b); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the specified hash function.
+func (s *hasher) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := s.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
 // GenHashFromIOReader generates a hash from an io.Reader using the specified hash function.
 func (s *hasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
 	h := s.HashFunc()
-	if _, err := io.Copy(h, r); err != nil {
+	if _, err := copyBuffer(h, r, 0); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
@@ -134,7 +550,7 @@ func (s *hasher) CmpHashAndString(hashA []byte, str string) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -147,7 +563,89 @@ func (s *hasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// hasher8 represents a generic hasher for implementing hash8 interface.
+type hasher8 struct {
+	name     string
+	HashFunc func() hash8
+}
+
+// Name returns the name of the algorithm.
+func (s *hasher8) Name() string {
+	return s.name
+}
+
+// newHash returns a new hash.Hash using the specified hash function.
+func (s *hasher8) newHash() hash.Hash {
+	return s.HashFunc()
+}
+
+// GenHashFromString generates a hash from a string using the specified hash function.
+func (s *hasher8) GenHashFromString(str string) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := h.Write([]byte(str)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// GenHashFromBytes generates a hash from a byte slice using the specified hash function.
+func (s *hasher8) GenHashFromBytes(b []byte) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := h.Write(b); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the specified hash function.
+func (s *hasher8) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := s.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// GenHashFromIOReader generates a hash from an io.Reader using the specified hash function.
+func (s *hasher8) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := copyBuffer(h, r, 0); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndString compares a hash and a string using the specified hash function.
+func (s *hasher8) CmpHashAndString(hashA []byte, str string) error {
+	hashB, err := s.GenHashFromString(str)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader compares a hash and an io.Reader using the specified hash function.
+func (s *hasher8) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := s.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -155,9 +653,20 @@ func (s *hasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 
 // hasher32 represents a generic hasher for implementing hash.Hash32 interface.
 type hasher32 struct {
+	name     string
 	HashFunc func() hash.Hash32
 }
 
+// Name returns the name of the algorithm.
+func (s *hasher32) Name() string {
+	return s.name
+}
+
+// newHash returns a new hash.Hash using the specified hash function.
+func (s *hasher32) newHash() hash.Hash {
+	return s.HashFunc()
+}
+
 // GenHashFromString generates a hash from a string using the specified hash function.
 func (s *hasher32) GenHashFromString(str string) ([]byte, error) {
 	h := s.HashFunc()
@@ -167,10 +676,32 @@ func (s *hasher32) GenHashFromString(str string) ([]byte, error) {
 	return h.Sum(nil), nil
 }
 
+// GenHashFromBytes generates a hash from a byte slice using the specified hash function.
+func (s *hasher32) GenHashFromBytes(b []byte) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := h.Write(b); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the specified hash function.
+func (s *hasher32) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := s.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
 // GenHashFromIOReader generates a hash from an io.Reader using the specified hash function.
 func (s *hasher32) GenHashFromIOReader(r io.Reader) ([]byte, error) {
 	h := s.HashFunc()
-	if _, err := io.Copy(h, r); err != nil {
+	if _, err := copyBuffer(h, r, 0); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
@@ -183,7 +714,7 @@ func (s *hasher32) CmpHashAndString(hashA []byte, str string) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -196,7 +727,7 @@ func (s *hasher32) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -204,9 +735,20 @@ func (s *hasher32) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 
 // hasher32 represents a generic hasher for implementing hash.Hash32 interface.
 type hasher64 struct {
+	name     string
 	HashFunc func() hash.Hash64
 }
 
+// Name returns the name of the algorithm.
+func (s *hasher64) Name() string {
+	return s.name
+}
+
+// newHash returns a new hash.Hash using the specified hash function.
+func (s *hasher64) newHash() hash.Hash {
+	return s.HashFunc()
+}
+
 // GenHashFromString generates a hash from a string using the specified hash function.
 func (s *hasher64) GenHashFromString(str string) ([]byte, error) {
 	h := s.HashFunc()
@@ -216,10 +758,32 @@ func (s *hasher64) GenHashFromString(str string) ([]byte, error) {
 	return h.Sum(nil), nil
 }
 
+// GenHashFromBytes generates a hash from a byte slice using the specified hash function.
+func (s *hasher64) GenHashFromBytes(b []byte) ([]byte, error) {
+	h := s.HashFunc()
+	if _, err := h.Write(b); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the specified hash function.
+func (s *hasher64) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := s.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
 // GenHashFromIOReader generates a hash from an io.Reader using the specified hash function.
 func (s *hasher64) GenHashFromIOReader(r io.Reader) ([]byte, error) {
 	h := s.HashFunc()
-	if _, err := io.Copy(h, r); err != nil {
+	if _, err := copyBuffer(h, r, 0); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
@@ -232,7 +796,7 @@ func (s *hasher64) CmpHashAndString(hashA []byte, str string) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -245,7 +809,7 @@ func (s *hasher64) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -2,15 +2,40 @@
 package hasher
 
 import (
+	"archive/tar"
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
+	"image"
+	"image/png"
 	"io"
+	"math/big"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
+// highwayHashTestKey is the key 0x00, 0x01, ..., 0x1f used by the HighwayHash test cases.
+var highwayHashTestKey = [32]byte{
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+}
+
 func TestHash_Generate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -109,581 +134,627 @@ func TestHash_Generate(t *testing.T) {
 			expectedErr: nil,
 		},
 		{
-			name:        "Failed to generate perceptual hash from string",
+			name:        "Generate sha3-256 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithPhash()},
-			expected:    "",
-			expectedErr: ErrPhashNotSupportedString,
+			opts:        []Option{WithSha3_256()},
+			expected:    "36f028580bb02cc8272a9a020f4200e346e276ae664e45ee80745574e2f5ab80",
+			expectedErr: nil,
 		},
 		{
-			name:        "Generate perceptual hash from io.Reader",
-			input:       filepath.Join("testdata", "test.jpg"),
+			name:        "Generate sha3-256 from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithPhash()},
-			expected:    "6917092734e3ec3a",
+			opts:        []Option{WithSha3_256()},
+			expected:    "7b3e4d928590743013fe8cb3b72df48802b4aede8a3f4e6cba65a148c51fb7ec",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv32 from string",
+			name:        "Generate sha3-384 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv32()},
-			expected:    "bc2c0be9",
+			opts:        []Option{WithSha3_384()},
+			expected:    "e516dabb23b6e30026863543282780a3ae0dccf05551cf0295178d7ff0f1b41eecb9db3ff219007c4e097260d58621bd",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv32 from io.Reader",
+			name:        "Generate sha3-384 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv32()},
-			expected:    "43ada300",
+			opts:        []Option{WithSha3_384()},
+			expected:    "eb2ae52621d1aafa66e0acc719175932395f469a78229b37dae5f1ba4ac973d7514ea8f24921df09731d1e36b3e00b52",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv32a from string",
+			name:        "Generate sha3-512 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv32a()},
-			expected:    "afd071e5",
+			opts:        []Option{WithSha3_512()},
+			expected:    "9ece086e9bac491fac5c1d1046ca11d737b92a2b2ebd93f005d7b710110c0a678288166e7fbe796883a4f2e9b3ca9f484f521d0ce464345cc1aec96779149c14",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv32a from io.Reader",
+			name:        "Generate sha3-512 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv32a()},
-			expected:    "2358b7f6",
+			opts:        []Option{WithSha3_512()},
+			expected:    "8f6fdff294ab86a1e2bf8355aa3c8392c081196f7335bfcf7e05c516bbb4f0a35004b0e0850f05f4d3dc7df1d0ea4ebb39a426bc6b604d6c28478fcdc122b6d7",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv64 from string",
+			name:        "Generate blake2b-256 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv64()},
-			expected:    "8c093f7e9fccbf69",
+			opts:        []Option{WithBlake2b_256()},
+			expected:    "928b20366943e2afd11ebc0eae2e53a93bf177a4fcf35bcc64d503704e65e202",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv64 from io.Reader",
+			name:        "Generate blake2b-256 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv64()},
-			expected:    "137ece8257075460",
+			opts:        []Option{WithBlake2b_256()},
+			expected:    "3fae2a9859fd4d871ee554a89ca475f4de569ea764b4b70bab51a9cd08acd546",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv64a from string",
+			name:        "Generate blake2b-512 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv64a()},
-			expected:    "f9e6e6ef197c2b25",
+			opts:        []Option{WithBlake2b_512()},
+			expected:    "a71079d42853dea26e453004338670a53814b78137ffbed07603a41d76a483aa9bc33b582f77d30a65e6f29a896c0411f38312e1d66e0bf16386c86a89bea572",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv64a from io.Reader",
+			name:        "Generate blake2b-512 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv64a()},
-			expected:    "a696a78da2a53bf6",
+			opts:        []Option{WithBlake2b_512()},
+			expected:    "ed88561d8897ca106ed0689406c96f64d9bd00bc81bb13e88774f693c15c5f60bf8636e7e7206700235d044abd59d2156678dc478dae635a8b4bb32a34bd5f10",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv128 from string",
+			name:        "Generate blake2s-256 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv128()},
-			expected:    "66ab2a8b6f757277b806e89c56faf339",
+			opts:        []Option{WithBlake2s_256()},
+			expected:    "f308fc02ce9172ad02a7d75800ecfc027109bc67987ea32aba9b8dcc7b10150e",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv128 from io.Reader",
+			name:        "Generate blake2s-256 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv128()},
-			expected:    "68958d70aeb8df5cd163c8a14d117b08",
+			opts:        []Option{WithBlake2s_256()},
+			expected:    "606c2d213d04fd830869bfff8176d3664a8cebc6f8dd81b5856e151b5481d114",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv128a from string",
+			name:        "Generate crc32c from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv128a()},
-			expected:    "69d061a9c5757277b806e99413dd99a5",
+			opts:        []Option{WithCRC32Castagnoli()},
+			expected:    "86a072c0",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate fnv128a from io.Reader",
+			name:        "Generate crc32c from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv128a()},
-			expected:    "2736af9add0bf02253c1651da64b19a6",
+			opts:        []Option{WithCRC32Castagnoli()},
+			expected:    "7145a2a2",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate blake3 from string",
+			name:        "Generate crc32-koopman from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithBlake3()},
-			expected:    "4878ca0425c739fa427f7eda20fe845f6b2e46ba5fe2a14df5b1e32f50603215c82f77a5bd07f7048a95a699e056d0e32bd2bdadc37ee096719c3d9ec12f29a6",
+			opts:        []Option{WithCRC32Koopman()},
+			expected:    "5c39ab1e",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate blake3 from io.Reader",
+			name:        "Generate crc32-koopman from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithBlake3()},
-			expected:    "a1b692dcd30210a76e8d24cb593a0b2f8d307dbe4dfdefd7053f725d6b996fe9ee2ea450c9c9afa2f654640ec4cf113c3420d2f3b71edd7c55ca11d918d1af2b",
+			opts:        []Option{WithCRC32Koopman()},
+			expected:    "818a5fed",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate adler32 from string",
+			name:        "Generate crc64-iso from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithAdler32()},
-			expected:    "045d01c1",
+			opts:        []Option{WithCRC64ISO()},
+			expected:    "287c72c850000000",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate adler32 from io.Reader",
+			name:        "Generate crc64-iso from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithAdler32()},
-			expected:    "0368237e",
+			opts:        []Option{WithCRC64ISO()},
+			expected:    "9b7800e6b4e77136",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate mmh3 from string",
+			name:        "Generate crc64-ecma from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithMmh3()},
-			expected:    "9de1bd74cc287dac824dbdf93182129a",
+			opts:        []Option{WithCRC64ECMA()},
+			expected:    "fa15fda7c10c75a5",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate mmh3 from io.Reader",
+			name:        "Generate crc64-ecma from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithMmh3()},
-			expected:    "44419d48e3c263f195119454fde57819",
+			opts:        []Option{WithCRC64ECMA()},
+			expected:    "8bce67935a259d4c",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate whirlpool from string",
+			name:        "Generate sha224 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithWhirlpool()},
-			expected:    "b913d5bbb8e461c2c5961cbe0edcdadfd29f068225ceb37da6defcf89849368f8c6c2eb6a4c4ac75775d032a0ecfdfe8550573062b653fe92fc7b8fb3b7be8d6",
+			opts:        []Option{WithSha224()},
+			expected:    "90a3ed9e32b2aaf4c61c410eb925426119e1a9dc53d4286ade99a809",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate whirlpool from io.Reader",
+			name:        "Generate sha224 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithWhirlpool()},
-			expected:    "00a1616b2be44c0d92fef873630421e01fd2d6c346502281595b818ca262f3211e52d72adc3cdc396c2953ba8e62d54805f0ae791e8d032c3df3b4ab84c2a4f3",
+			opts:        []Option{WithSha224()},
+			expected:    "abacd408f6c6a022fcef5f765750a9af23f9188f72fe4558b5489e90",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate crc32 from string",
+			name:        "Generate sha384 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithCRC32()},
-			expected:    "d87f7e0c",
+			opts:        []Option{WithSha384()},
+			expected:    "768412320f7b0aa5812fce428dc4706b3cae50e02a64caa16a782249bfe8efc4b7ef1ccb126255d196047dfedf17a0a9",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate crc32 from io.Reader",
+			name:        "Generate sha384 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithCRC32()},
-			expected:    "5c98c4e4",
+			opts:        []Option{WithSha384()},
+			expected:    "2350d6a56cf73e44c28fc103a59c441c955255090b1466a093841a90bd6ab66ed32175b7852f617c8e4b30cc49260e2d",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate xxHash from string",
+			name:        "Generate ripemd160 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithXXHash()},
-			expected:    "4fdcca5ddb678139",
+			opts:        []Option{WithRipemd160()},
+			expected:    "5e52fee47e6b070565f74372468cdc699de89107",
 			expectedErr: nil,
 		},
 		{
-			name:        "Generate xxHash from io.Reader",
+			name:        "Generate ripemd160 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithXXHash()},
-			expected:    "29081d4d3fb56bc6",
+			opts:        []Option{WithRipemd160()},
+			expected:    "cefb381cd610ee04e1f281da1af8fcad25b36ac9",
 			expectedErr: nil,
 		},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			h := NewHash(tt.opts...)
-
-			var input any
-			input = tt.input
-			if tt.isFile {
-				i, ok := tt.input.(string)
-				if !ok {
-					t.Fatalf("input is not a string")
-				}
-
-				f, err := os.Open(filepath.Clean(i))
-				if err != nil {
-					t.Fatalf("os.Open() error = %v", err)
-				}
-				defer f.Close() //nolint:errcheck
-
-				input = f
-			}
-
-			got, err := h.Generate(input)
-			if tt.expectedErr != nil {
-				if !errors.Is(err, tt.expectedErr) {
-					t.Errorf("Hash.Generate() error = %v, want %v", err, tt.expectedErr)
-				}
-				return
-			}
-
-			if err != nil {
-				t.Fatalf("Hash.Generate() error = %v", err)
-			}
-
-			expectedBytes, err := hex.DecodeString(tt.expected)
-			if err != nil {
-				t.Fatalf("hex.DecodeString() error = %v", err)
-			}
-
-			if !bytes.Equal(got, expectedBytes) {
-				t.Errorf("Hash.Generate() = %x, want %x", got, expectedBytes)
-			}
-		})
-	}
-}
-func TestHash_Compare(t *testing.T) {
-	tests := []struct {
-		name        string
-		hash        string
-		input       interface{}
-		isFile      bool
-		opts        []Option
-		expectedErr error
-	}{
 		{
-			name:        "Compare md5sum hash and string",
-			hash:        "098f6bcd4621d373cade4e832627b4f6",
+			name:        "Generate HighwayHash from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{},
+			opts:        []Option{WithHighwayHash(highwayHashTestKey)},
+			expected:    "ef062daee7d686d9cd9d020f2c8ab18b96bcb3af08dd556f09478169da51cc29",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare md5sum hash and io.Reader",
-			hash:        "7b4bc55c9a1295ecbd2b77a636565f27",
+			name:        "Generate HighwayHash from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithMd5()},
+			opts:        []Option{WithHighwayHash(highwayHashTestKey)},
+			expected:    "62b1c40b5974339482a21588518edbda0c6a4b87ef154d5cdc62253eed115fe9",
 			expectedErr: nil,
 		},
 		{
-			name:        "Hash mismatch: input type is string",
-			hash:        "098f6bcd4621d373cade4e832627b4f6",
-			input:       "mismatch_string",
+			name:        "Generate XXH3-64 from string",
+			input:       "test",
 			isFile:      false,
-			opts:        []Option{},
-			expectedErr: ErrHashMismatch,
+			opts:        []Option{WithXXH3_64()},
+			expected:    "9ec9f7918d7dfc40",
+			expectedErr: nil,
 		},
 		{
-			name:        "Hash mismatch: input type is io.Reader",
-			hash:        "7b4bc55c9a1295ecbd2b77a636565f27",
-			input:       filepath.Join("testdata", "mismatch.txt"),
+			name:        "Generate XXH3-64 from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithMd5()},
-			expectedErr: ErrHashMismatch,
-		},
-		{
-			name:        "Unsupported input type",
-			hash:        "098f6bcd4621d373cade4e832627b4f6",
-			input:       1,
-			isFile:      false,
-			opts:        []Option{},
-			expectedErr: ErrUnsupportedInputType,
+			opts:        []Option{WithXXH3_64()},
+			expected:    "3c93bc3ae26030da",
+			expectedErr: nil,
 		},
 		{
-			name:        "Compare user-defined hash and string",
-			hash:        "0c",
+			name:        "Generate XXH3-128 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithUserDifinedAlgorithm(&userHash{})},
+			opts:        []Option{WithXXH3_128()},
+			expected:    "6c78e0e3bd51d358d01e758642b85fb8",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare user-defined hash and io.Reader",
-			hash:        "22",
+			name:        "Generate XXH3-128 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithUserDifinedAlgorithm(&userHash{})},
+			opts:        []Option{WithXXH3_128()},
+			expected:    "17c256dd49a20a150a8f6e47475861b5",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare sha1 hash and string",
-			hash:        "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+			name:        "Generate SHAKE128 (32 bytes) from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithSha1()},
+			opts:        []Option{WithShake128(32)},
+			expected:    "d3b0aa9cd8b7255622cebc631e867d4093d6f6010191a53973c45fec9b07c774",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare sha1 hash and io.Reader",
-			hash:        "734202c47ed7157e3c187e1fd7ba8eb6e0b58fa4",
+			name:        "Generate SHAKE128 (32 bytes) from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithSha1()},
+			opts:        []Option{WithShake128(32)},
+			expected:    "dd4402f8ad4781649405265d0055a46c26ae17965ae1d80bdda679889cb65d06",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare sha256 hash and string",
-			hash:        "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			name:        "Generate SHAKE256 (16 bytes) from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithSha256()},
+			opts:        []Option{WithShake256(16)},
+			expected:    "b54ff7255705a71ee2925e4a3e30e41a",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare s sha256 hash and io.Reader",
-			hash:        "f2e0d62d29081f468bf7ac70415fc4cc391d877fb7ef09c614c7bdd5f9175b99",
+			name:        "Generate SHAKE256 (16 bytes) from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithSha256()},
+			opts:        []Option{WithShake256(16)},
+			expected:    "a742298553eb4213cd63c6ab32f398da",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare sha512 hash and string",
-			hash:        "ee26b0dd4af7e749aa1a8ee3c10ae9923f618980772e473f8819a5d4940e0db27ac185f8a0e1d5f84f88bc887fd67b143732c304cc5fa9ad8e6f57f50028a8ff",
+			name:        "Generate SHAKE256 (64 bytes) from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithSha512()},
+			opts:        []Option{WithShake256(64)},
+			expected:    "b54ff7255705a71ee2925e4a3e30e41aed489a579d5595e0df13e32e1e4dd202a7c7f68b31d6418d9845eb4d757adda6ab189e1bb340db818e5b3bc725d992fa",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare sha512 hash and io.Reader",
-			hash:        "9e7021341882d2a4cae911cf08b0312a10c8edff7aa279adb43b2c2646bece9281da78e2d6e84c048b9ff70730990bfd201240c18b6e053b2027605690671418",
+			name:        "Generate SHAKE256 (64 bytes) from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithSha512()},
+			opts:        []Option{WithShake256(64)},
+			expected:    "a742298553eb4213cd63c6ab32f398dab5aaf78fd0c3f2924de4b989830c553a3890f2f8dedd527392dd074dd6b6432a385266dccba5c4b3aed98411e9941999",
 			expectedErr: nil,
 		},
 		{
-			name:        "Failed to compare perceptual hash and string",
-			hash:        "6917092734e3ec3a",
+			name:        "Generate FarmHash64 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithPhash()},
-			expectedErr: ErrPhashNotSupportedString,
+			opts:        []Option{WithFarmHash64()},
+			expected:    "7717383daa85b5b2",
+			expectedErr: nil,
 		},
 		{
-			name:        "Compare perceptual hash and io.Reader",
-			hash:        "6917092734e3ec3a",
-			input:       filepath.Join("testdata", "test.jpg"),
+			name:        "Generate FarmHash64 from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithPhash()},
+			opts:        []Option{WithFarmHash64()},
+			expected:    "127da387de60c720",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv32 hash and string",
-			hash:        "bc2c0be9",
+			name:        "Generate MetroHash64 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv32()},
+			opts:        []Option{WithMetroHash64()},
+			expected:    "b2baf77de212d136",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv32 hash and io.Reader",
-			hash:        "43ada300",
+			name:        "Generate MetroHash64 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv32()},
+			opts:        []Option{WithMetroHash64()},
+			expected:    "6de410554b178901",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv32a hash and string",
-			hash:        "afd071e5",
+			name:        "Generate MetroHash128 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv32a()},
+			opts:        []Option{WithMetroHash128()},
+			expected:    "666deac1207c7d8fcd06ab4651c48a71",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv32a hash and io.Reader",
-			hash:        "2358b7f6",
+			name:        "Generate MetroHash128 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv32a()},
+			opts:        []Option{WithMetroHash128()},
+			expected:    "b8ce355d3e78ef254997ee6f1d3cdc06",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv64 hash and string",
-			hash:        "8c093f7e9fccbf69",
+			name:        "Generate keccak256 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv64()},
+			opts:        []Option{WithKeccak256()},
+			expected:    "9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv64 hash and io.Reader",
-			hash:        "137ece8257075460",
+			name:        "Generate keccak256 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv64()},
+			opts:        []Option{WithKeccak256()},
+			expected:    "ddc3c0ecd0fca52e07f2bd1dde8ae0fea9244a302843d29ed737a60b746c5efc",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv64a hash and string",
-			hash:        "f9e6e6ef197c2b25",
+			name:        "Failed to generate perceptual hash from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv64a()},
-			expectedErr: nil,
+			opts:        []Option{WithPhash()},
+			expected:    "",
+			expectedErr: ErrPhashNotSupportedString,
 		},
 		{
-			name:        "Compare fnv64a hash and io.Reader",
-			hash:        "a696a78da2a53bf6",
-			input:       filepath.Join("testdata", "test.txt"),
+			name:        "Generate perceptual hash from io.Reader",
+			input:       filepath.Join("testdata", "test.jpg"),
 			isFile:      true,
-			opts:        []Option{WithFnv64a()},
+			opts:        []Option{WithPhash()},
+			expected:    "6917092734e3ec3a",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv128 hash and string",
-			hash:        "66ab2a8b6f757277b806e89c56faf339",
+			name:        "Generate fnv32 from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv128()},
+			opts:        []Option{WithFnv32()},
+			expected:    "bc2c0be9",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv128 hash and io.Reader",
-			hash:        "68958d70aeb8df5cd163c8a14d117b08",
+			name:        "Generate fnv32 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
-			opts:        []Option{WithFnv128()},
+			opts:        []Option{WithFnv32()},
+			expected:    "43ada300",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv128a hash and string",
-			hash:        "69d061a9c5757277b806e99413dd99a5",
+			name:        "Generate fnv32a from string",
 			input:       "test",
 			isFile:      false,
-			opts:        []Option{WithFnv128a()},
-			expectedErr: nil,
+			opts:        []Option{WithFnv32a()},
+			expected:    "afd071e5",
+			expectedErr: nil,
 		},
 		{
-			name:        "Compare fnv128a hash and io.Reader",
-			hash:        "2736af9add0bf02253c1651da64b19a6",
+			name:        "Generate fnv32a from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv32a()},
+			expected:    "2358b7f6",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv64 from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv64()},
+			expected:    "8c093f7e9fccbf69",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv64 from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv64()},
+			expected:    "137ece8257075460",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv64a from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv64a()},
+			expected:    "f9e6e6ef197c2b25",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv64a from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv64a()},
+			expected:    "a696a78da2a53bf6",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv128 from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv128()},
+			expected:    "66ab2a8b6f757277b806e89c56faf339",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv128 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
+			opts:        []Option{WithFnv128()},
+			expected:    "68958d70aeb8df5cd163c8a14d117b08",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate fnv128a from string",
+			input:       "test",
+			isFile:      false,
 			opts:        []Option{WithFnv128a()},
+			expected:    "69d061a9c5757277b806e99413dd99a5",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare blake3 hash and string",
-			hash:        "4878ca0425c739fa427f7eda20fe845f6b2e46ba5fe2a14df5b1e32f50603215c82f77a5bd07f7048a95a699e056d0e32bd2bdadc37ee096719c3d9ec12f29a6",
+			name:        "Generate fnv128a from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv128a()},
+			expected:    "2736af9add0bf02253c1651da64b19a6",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate blake3 from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithBlake3()},
+			expected:    "4878ca0425c739fa427f7eda20fe845f6b2e46ba5fe2a14df5b1e32f50603215c82f77a5bd07f7048a95a699e056d0e32bd2bdadc37ee096719c3d9ec12f29a6",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare blake3 hash and io.Reader",
-			hash:        "a1b692dcd30210a76e8d24cb593a0b2f8d307dbe4dfdefd7053f725d6b996fe9ee2ea450c9c9afa2f654640ec4cf113c3420d2f3b71edd7c55ca11d918d1af2b",
+			name:        "Generate blake3 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithBlake3()},
+			expected:    "a1b692dcd30210a76e8d24cb593a0b2f8d307dbe4dfdefd7053f725d6b996fe9ee2ea450c9c9afa2f654640ec4cf113c3420d2f3b71edd7c55ca11d918d1af2b",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare adler32 hash and string",
-			hash:        "045d01c1",
+			name:        "Generate adler32 from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithAdler32()},
+			expected:    "045d01c1",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare adler32 hash and io.Reader",
-			hash:        "0368237e",
+			name:        "Generate adler32 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithAdler32()},
+			expected:    "0368237e",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare mmh3 hash and string",
-			hash:        "9de1bd74cc287dac824dbdf93182129a",
+			name:        "Generate mmh3 from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithMmh3()},
+			expected:    "9de1bd74cc287dac824dbdf93182129a",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare mmh3 hash and io.Reader",
-			hash:        "44419d48e3c263f195119454fde57819",
+			name:        "Generate mmh3 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithMmh3()},
+			expected:    "44419d48e3c263f195119454fde57819",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare whirlpool hash and string",
-			hash:        "b913d5bbb8e461c2c5961cbe0edcdadfd29f068225ceb37da6defcf89849368f8c6c2eb6a4c4ac75775d032a0ecfdfe8550573062b653fe92fc7b8fb3b7be8d6",
+			name:        "Generate whirlpool from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithWhirlpool()},
+			expected:    "b913d5bbb8e461c2c5961cbe0edcdadfd29f068225ceb37da6defcf89849368f8c6c2eb6a4c4ac75775d032a0ecfdfe8550573062b653fe92fc7b8fb3b7be8d6",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare whirlpool hash and io.Reader",
-			hash:        "00a1616b2be44c0d92fef873630421e01fd2d6c346502281595b818ca262f3211e52d72adc3cdc396c2953ba8e62d54805f0ae791e8d032c3df3b4ab84c2a4f3",
+			name:        "Generate whirlpool from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithWhirlpool()},
+			expected:    "00a1616b2be44c0d92fef873630421e01fd2d6c346502281595b818ca262f3211e52d72adc3cdc396c2953ba8e62d54805f0ae791e8d032c3df3b4ab84c2a4f3",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare crc32 hash and string",
-			hash:        "d87f7e0c",
+			name:        "Generate crc32 from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithCRC32()},
+			expected:    "d87f7e0c",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare crc32 hash and io.Reader",
-			hash:        "5c98c4e4",
+			name:        "Generate crc32 from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithCRC32()},
+			expected:    "5c98c4e4",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare xxHash hash and string",
-			hash:        "4fdcca5ddb678139",
+			name:        "Generate crc8 from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC8(0x1d)},
+			expected:    "be",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate crc8 from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC8(0x1d)},
+			expected:    "e4",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate crc8-smbus from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC8SMBus()},
+			expected:    "b9",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate crc8-smbus from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC8SMBus()},
+			expected:    "e3",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate crc8-maxim from string",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC8Maxim()},
+			expected:    "4c",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate crc8-maxim from io.Reader",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC8Maxim()},
+			expected:    "f7",
+			expectedErr: nil,
+		},
+		{
+			name:        "Generate xxHash from string",
 			input:       "test",
 			isFile:      false,
 			opts:        []Option{WithXXHash()},
+			expected:    "4fdcca5ddb678139",
 			expectedErr: nil,
 		},
 		{
-			name:        "Compare xxHash hash and io.Reader",
-			hash:        "29081d4d3fb56bc6",
+			name:        "Generate xxHash from io.Reader",
 			input:       filepath.Join("testdata", "test.txt"),
 			isFile:      true,
 			opts:        []Option{WithXXHash()},
+			expected:    "29081d4d3fb56bc6",
 			expectedErr: nil,
 		},
 	}
@@ -693,61 +764,5127 @@ func TestHash_Compare(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			hashBytes, err := hex.DecodeString(tt.hash)
-			if err != nil {
-				t.Fatalf("hex.DecodeString() error = %v", err)
-			}
-
 			h := NewHash(tt.opts...)
 
 			var input any
 			input = tt.input
 			if tt.isFile {
-				if _, ok := tt.input.(string); ok {
-					i, ok := tt.input.(string)
-					if !ok {
-						t.Fatalf("input is not a string")
-					}
-
-					f, err := os.Open(filepath.Clean(i))
-					if err != nil {
-						t.Fatalf("os.Open() error = %v", err)
-					}
-					defer f.Close() //nolint:errcheck
+				i, ok := tt.input.(string)
+				if !ok {
+					t.Fatalf("input is not a string")
+				}
 
-					input = f
+				f, err := os.Open(filepath.Clean(i))
+				if err != nil {
+					t.Fatalf("os.Open() error = %v", err)
 				}
+				defer f.Close() //nolint:errcheck
+
+				input = f
 			}
 
-			err = h.Compare(hashBytes, input)
+			got, err := h.Generate(input)
 			if tt.expectedErr != nil {
 				if !errors.Is(err, tt.expectedErr) {
-					t.Errorf("Hash.Compare() error = %v, want %v", err, tt.expectedErr)
+					t.Errorf("Hash.Generate() error = %v, want %v", err, tt.expectedErr)
 				}
 				return
 			}
 
 			if err != nil {
-				t.Fatalf("Hash.Compare() error = %v", err)
+				t.Fatalf("Hash.Generate() error = %v", err)
 			}
-		})
-	}
-}
-
-type userHash struct{}
-
-func (u *userHash) GenHashFromString(_ string) ([]byte, error) {
-	return []byte{116, 101, 115, 116}, nil // "test"
-}
 
-func (u *userHash) GenHashFromIOReader(_ io.Reader) ([]byte, error) {
-	return []byte{116, 101, 115, 116}, nil // "test"
-}
+			expectedBytes, err := hex.DecodeString(tt.expected)
+			if err != nil {
+				t.Fatalf("hex.DecodeString() error = %v", err)
+			}
 
-func (u *userHash) CmpHashAndString(hash []byte, s string) error {
-	return nil
+			if !bytes.Equal(got, expectedBytes) {
+				t.Errorf("Hash.Generate() = %x, want %x", got, expectedBytes)
+			}
+		})
+	}
 }
-
-func (u *userHash) CmpHashAndIOReader(hash []byte, r io.Reader) error {
-	return nil
+func TestHash_Compare(t *testing.T) {
+	tests := []struct {
+		name        string
+		hash        string
+		input       interface{}
+		isFile      bool
+		opts        []Option
+		expectedErr error
+	}{
+		{
+			name:        "Compare md5sum hash and string",
+			hash:        "098f6bcd4621d373cade4e832627b4f6",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare md5sum hash and io.Reader",
+			hash:        "7b4bc55c9a1295ecbd2b77a636565f27",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithMd5()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: input type is string",
+			hash:        "098f6bcd4621d373cade4e832627b4f6",
+			input:       "mismatch_string",
+			isFile:      false,
+			opts:        []Option{},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Hash mismatch: input type is io.Reader",
+			hash:        "7b4bc55c9a1295ecbd2b77a636565f27",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithMd5()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Unsupported input type",
+			hash:        "098f6bcd4621d373cade4e832627b4f6",
+			input:       1,
+			isFile:      false,
+			opts:        []Option{},
+			expectedErr: ErrUnsupportedInputType,
+		},
+		{
+			name:        "Compare user-defined hash and string",
+			hash:        "0c",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithUserDifinedAlgorithm(&userHash{})},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare user-defined hash and io.Reader",
+			hash:        "22",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithUserDifinedAlgorithm(&userHash{})},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha1 hash and string",
+			hash:        "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha1()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha1 hash and io.Reader",
+			hash:        "734202c47ed7157e3c187e1fd7ba8eb6e0b58fa4",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha1()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha256 hash and string",
+			hash:        "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare s sha256 hash and io.Reader",
+			hash:        "f2e0d62d29081f468bf7ac70415fc4cc391d877fb7ef09c614c7bdd5f9175b99",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha512 hash and string",
+			hash:        "ee26b0dd4af7e749aa1a8ee3c10ae9923f618980772e473f8819a5d4940e0db27ac185f8a0e1d5f84f88bc887fd67b143732c304cc5fa9ad8e6f57f50028a8ff",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha512 hash and io.Reader",
+			hash:        "9e7021341882d2a4cae911cf08b0312a10c8edff7aa279adb43b2c2646bece9281da78e2d6e84c048b9ff70730990bfd201240c18b6e053b2027605690671418",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha3-256 hash and string",
+			hash:        "36f028580bb02cc8272a9a020f4200e346e276ae664e45ee80745574e2f5ab80",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha3_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha3-256 hash and io.Reader",
+			hash:        "7b3e4d928590743013fe8cb3b72df48802b4aede8a3f4e6cba65a148c51fb7ec",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: sha3-256 hash and io.Reader",
+			hash:        "7b3e4d928590743013fe8cb3b72df48802b4aede8a3f4e6cba65a148c51fb7ec",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_256()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare sha3-384 hash and string",
+			hash:        "e516dabb23b6e30026863543282780a3ae0dccf05551cf0295178d7ff0f1b41eecb9db3ff219007c4e097260d58621bd",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha3_384()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha3-384 hash and io.Reader",
+			hash:        "eb2ae52621d1aafa66e0acc719175932395f469a78229b37dae5f1ba4ac973d7514ea8f24921df09731d1e36b3e00b52",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_384()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: sha3-384 hash and io.Reader",
+			hash:        "eb2ae52621d1aafa66e0acc719175932395f469a78229b37dae5f1ba4ac973d7514ea8f24921df09731d1e36b3e00b52",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_384()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare sha3-512 hash and string",
+			hash:        "9ece086e9bac491fac5c1d1046ca11d737b92a2b2ebd93f005d7b710110c0a678288166e7fbe796883a4f2e9b3ca9f484f521d0ce464345cc1aec96779149c14",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha3_512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha3-512 hash and io.Reader",
+			hash:        "8f6fdff294ab86a1e2bf8355aa3c8392c081196f7335bfcf7e05c516bbb4f0a35004b0e0850f05f4d3dc7df1d0ea4ebb39a426bc6b604d6c28478fcdc122b6d7",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: sha3-512 hash and io.Reader",
+			hash:        "8f6fdff294ab86a1e2bf8355aa3c8392c081196f7335bfcf7e05c516bbb4f0a35004b0e0850f05f4d3dc7df1d0ea4ebb39a426bc6b604d6c28478fcdc122b6d7",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha3_512()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare blake2b-256 hash and string",
+			hash:        "928b20366943e2afd11ebc0eae2e53a93bf177a4fcf35bcc64d503704e65e202",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithBlake2b_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare blake2b-256 hash and io.Reader",
+			hash:        "3fae2a9859fd4d871ee554a89ca475f4de569ea764b4b70bab51a9cd08acd546",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2b_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: blake2b-256 hash and io.Reader",
+			hash:        "3fae2a9859fd4d871ee554a89ca475f4de569ea764b4b70bab51a9cd08acd546",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2b_256()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare blake2b-512 hash and string",
+			hash:        "a71079d42853dea26e453004338670a53814b78137ffbed07603a41d76a483aa9bc33b582f77d30a65e6f29a896c0411f38312e1d66e0bf16386c86a89bea572",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithBlake2b_512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare blake2b-512 hash and io.Reader",
+			hash:        "ed88561d8897ca106ed0689406c96f64d9bd00bc81bb13e88774f693c15c5f60bf8636e7e7206700235d044abd59d2156678dc478dae635a8b4bb32a34bd5f10",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2b_512()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: blake2b-512 hash and io.Reader",
+			hash:        "ed88561d8897ca106ed0689406c96f64d9bd00bc81bb13e88774f693c15c5f60bf8636e7e7206700235d044abd59d2156678dc478dae635a8b4bb32a34bd5f10",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2b_512()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare blake2s-256 hash and string",
+			hash:        "f308fc02ce9172ad02a7d75800ecfc027109bc67987ea32aba9b8dcc7b10150e",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithBlake2s_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare blake2s-256 hash and io.Reader",
+			hash:        "606c2d213d04fd830869bfff8176d3664a8cebc6f8dd81b5856e151b5481d114",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2s_256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: blake2s-256 hash and io.Reader",
+			hash:        "606c2d213d04fd830869bfff8176d3664a8cebc6f8dd81b5856e151b5481d114",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake2s_256()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare crc32c hash and string",
+			hash:        "86a072c0",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC32Castagnoli()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc32c hash and io.Reader",
+			hash:        "7145a2a2",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC32Castagnoli()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: crc32c hash and io.Reader",
+			hash:        "7145a2a2",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC32Castagnoli()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare crc32-koopman hash and string",
+			hash:        "5c39ab1e",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC32Koopman()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc32-koopman hash and io.Reader",
+			hash:        "818a5fed",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC32Koopman()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: crc32-koopman hash and io.Reader",
+			hash:        "818a5fed",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC32Koopman()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare crc64-iso hash and string",
+			hash:        "287c72c850000000",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC64ISO()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc64-iso hash and io.Reader",
+			hash:        "9b7800e6b4e77136",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC64ISO()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: crc64-iso hash and io.Reader",
+			hash:        "9b7800e6b4e77136",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC64ISO()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare crc64-ecma hash and string",
+			hash:        "fa15fda7c10c75a5",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC64ECMA()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc64-ecma hash and io.Reader",
+			hash:        "8bce67935a259d4c",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC64ECMA()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: crc64-ecma hash and io.Reader",
+			hash:        "8bce67935a259d4c",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC64ECMA()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare sha224 hash and string",
+			hash:        "90a3ed9e32b2aaf4c61c410eb925426119e1a9dc53d4286ade99a809",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha224()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha224 hash and io.Reader",
+			hash:        "abacd408f6c6a022fcef5f765750a9af23f9188f72fe4558b5489e90",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha224()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: sha224 hash and io.Reader",
+			hash:        "abacd408f6c6a022fcef5f765750a9af23f9188f72fe4558b5489e90",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha224()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare sha384 hash and string",
+			hash:        "768412320f7b0aa5812fce428dc4706b3cae50e02a64caa16a782249bfe8efc4b7ef1ccb126255d196047dfedf17a0a9",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithSha384()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare sha384 hash and io.Reader",
+			hash:        "2350d6a56cf73e44c28fc103a59c441c955255090b1466a093841a90bd6ab66ed32175b7852f617c8e4b30cc49260e2d",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha384()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: sha384 hash and io.Reader",
+			hash:        "2350d6a56cf73e44c28fc103a59c441c955255090b1466a093841a90bd6ab66ed32175b7852f617c8e4b30cc49260e2d",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithSha384()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare ripemd160 hash and string",
+			hash:        "5e52fee47e6b070565f74372468cdc699de89107",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithRipemd160()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare ripemd160 hash and io.Reader",
+			hash:        "cefb381cd610ee04e1f281da1af8fcad25b36ac9",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithRipemd160()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: ripemd160 hash and io.Reader",
+			hash:        "cefb381cd610ee04e1f281da1af8fcad25b36ac9",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithRipemd160()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare HighwayHash hash and string",
+			hash:        "ef062daee7d686d9cd9d020f2c8ab18b96bcb3af08dd556f09478169da51cc29",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithHighwayHash(highwayHashTestKey)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare HighwayHash hash and io.Reader",
+			hash:        "62b1c40b5974339482a21588518edbda0c6a4b87ef154d5cdc62253eed115fe9",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithHighwayHash(highwayHashTestKey)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: HighwayHash hash and io.Reader",
+			hash:        "62b1c40b5974339482a21588518edbda0c6a4b87ef154d5cdc62253eed115fe9",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithHighwayHash(highwayHashTestKey)},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare XXH3-64 hash and string",
+			hash:        "9ec9f7918d7dfc40",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithXXH3_64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare XXH3-64 hash and io.Reader",
+			hash:        "3c93bc3ae26030da",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithXXH3_64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: XXH3-64 hash and io.Reader",
+			hash:        "3c93bc3ae26030da",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithXXH3_64()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare XXH3-128 hash and string",
+			hash:        "6c78e0e3bd51d358d01e758642b85fb8",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithXXH3_128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare XXH3-128 hash and io.Reader",
+			hash:        "17c256dd49a20a150a8f6e47475861b5",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithXXH3_128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: XXH3-128 hash and io.Reader",
+			hash:        "17c256dd49a20a150a8f6e47475861b5",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithXXH3_128()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare SHAKE128 (32 bytes) hash and string",
+			hash:        "d3b0aa9cd8b7255622cebc631e867d4093d6f6010191a53973c45fec9b07c774",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithShake128(32)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare SHAKE128 (32 bytes) hash and io.Reader",
+			hash:        "dd4402f8ad4781649405265d0055a46c26ae17965ae1d80bdda679889cb65d06",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake128(32)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: SHAKE128 (32 bytes) hash and io.Reader",
+			hash:        "dd4402f8ad4781649405265d0055a46c26ae17965ae1d80bdda679889cb65d06",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake128(32)},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare SHAKE256 (16 bytes) hash and string",
+			hash:        "b54ff7255705a71ee2925e4a3e30e41a",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithShake256(16)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare SHAKE256 (16 bytes) hash and io.Reader",
+			hash:        "a742298553eb4213cd63c6ab32f398da",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake256(16)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: SHAKE256 (16 bytes) hash and io.Reader",
+			hash:        "a742298553eb4213cd63c6ab32f398da",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake256(16)},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare SHAKE256 (64 bytes) hash and string",
+			hash:        "b54ff7255705a71ee2925e4a3e30e41aed489a579d5595e0df13e32e1e4dd202a7c7f68b31d6418d9845eb4d757adda6ab189e1bb340db818e5b3bc725d992fa",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithShake256(64)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare SHAKE256 (64 bytes) hash and io.Reader",
+			hash:        "a742298553eb4213cd63c6ab32f398dab5aaf78fd0c3f2924de4b989830c553a3890f2f8dedd527392dd074dd6b6432a385266dccba5c4b3aed98411e9941999",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake256(64)},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: SHAKE256 (64 bytes) hash and io.Reader",
+			hash:        "a742298553eb4213cd63c6ab32f398dab5aaf78fd0c3f2924de4b989830c553a3890f2f8dedd527392dd074dd6b6432a385266dccba5c4b3aed98411e9941999",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithShake256(64)},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare FarmHash64 hash and string",
+			hash:        "7717383daa85b5b2",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFarmHash64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare FarmHash64 hash and io.Reader",
+			hash:        "127da387de60c720",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFarmHash64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: FarmHash64 hash and io.Reader",
+			hash:        "127da387de60c720",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithFarmHash64()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare MetroHash64 hash and string",
+			hash:        "b2baf77de212d136",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithMetroHash64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare MetroHash64 hash and io.Reader",
+			hash:        "6de410554b178901",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithMetroHash64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: MetroHash64 hash and io.Reader",
+			hash:        "6de410554b178901",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithMetroHash64()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare MetroHash128 hash and string",
+			hash:        "666deac1207c7d8fcd06ab4651c48a71",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithMetroHash128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare MetroHash128 hash and io.Reader",
+			hash:        "b8ce355d3e78ef254997ee6f1d3cdc06",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithMetroHash128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: MetroHash128 hash and io.Reader",
+			hash:        "b8ce355d3e78ef254997ee6f1d3cdc06",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithMetroHash128()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Compare keccak256 hash and string",
+			hash:        "9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithKeccak256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare keccak256 hash and io.Reader",
+			hash:        "ddc3c0ecd0fca52e07f2bd1dde8ae0fea9244a302843d29ed737a60b746c5efc",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithKeccak256()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Hash mismatch: keccak256 hash and io.Reader",
+			hash:        "ddc3c0ecd0fca52e07f2bd1dde8ae0fea9244a302843d29ed737a60b746c5efc",
+			input:       filepath.Join("testdata", "mismatch.txt"),
+			isFile:      true,
+			opts:        []Option{WithKeccak256()},
+			expectedErr: ErrHashMismatch,
+		},
+		{
+			name:        "Failed to compare perceptual hash and string",
+			hash:        "6917092734e3ec3a",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithPhash()},
+			expectedErr: ErrPhashNotSupportedString,
+		},
+		{
+			name:        "Compare perceptual hash and io.Reader",
+			hash:        "6917092734e3ec3a",
+			input:       filepath.Join("testdata", "test.jpg"),
+			isFile:      true,
+			opts:        []Option{WithPhash()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv32 hash and string",
+			hash:        "bc2c0be9",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv32 hash and io.Reader",
+			hash:        "43ada300",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv32a hash and string",
+			hash:        "afd071e5",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv32a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv32a hash and io.Reader",
+			hash:        "2358b7f6",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv32a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv64 hash and string",
+			hash:        "8c093f7e9fccbf69",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv64 hash and io.Reader",
+			hash:        "137ece8257075460",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv64()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv64a hash and string",
+			hash:        "f9e6e6ef197c2b25",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv64a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv64a hash and io.Reader",
+			hash:        "a696a78da2a53bf6",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv64a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv128 hash and string",
+			hash:        "66ab2a8b6f757277b806e89c56faf339",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv128 hash and io.Reader",
+			hash:        "68958d70aeb8df5cd163c8a14d117b08",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv128()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv128a hash and string",
+			hash:        "69d061a9c5757277b806e99413dd99a5",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithFnv128a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare fnv128a hash and io.Reader",
+			hash:        "2736af9add0bf02253c1651da64b19a6",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithFnv128a()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare blake3 hash and string",
+			hash:        "4878ca0425c739fa427f7eda20fe845f6b2e46ba5fe2a14df5b1e32f50603215c82f77a5bd07f7048a95a699e056d0e32bd2bdadc37ee096719c3d9ec12f29a6",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithBlake3()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare blake3 hash and io.Reader",
+			hash:        "a1b692dcd30210a76e8d24cb593a0b2f8d307dbe4dfdefd7053f725d6b996fe9ee2ea450c9c9afa2f654640ec4cf113c3420d2f3b71edd7c55ca11d918d1af2b",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithBlake3()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare adler32 hash and string",
+			hash:        "045d01c1",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithAdler32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare adler32 hash and io.Reader",
+			hash:        "0368237e",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithAdler32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare mmh3 hash and string",
+			hash:        "9de1bd74cc287dac824dbdf93182129a",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithMmh3()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare mmh3 hash and io.Reader",
+			hash:        "44419d48e3c263f195119454fde57819",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithMmh3()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare whirlpool hash and string",
+			hash:        "b913d5bbb8e461c2c5961cbe0edcdadfd29f068225ceb37da6defcf89849368f8c6c2eb6a4c4ac75775d032a0ecfdfe8550573062b653fe92fc7b8fb3b7be8d6",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithWhirlpool()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare whirlpool hash and io.Reader",
+			hash:        "00a1616b2be44c0d92fef873630421e01fd2d6c346502281595b818ca262f3211e52d72adc3cdc396c2953ba8e62d54805f0ae791e8d032c3df3b4ab84c2a4f3",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithWhirlpool()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc32 hash and string",
+			hash:        "d87f7e0c",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithCRC32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare crc32 hash and io.Reader",
+			hash:        "5c98c4e4",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithCRC32()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare xxHash hash and string",
+			hash:        "4fdcca5ddb678139",
+			input:       "test",
+			isFile:      false,
+			opts:        []Option{WithXXHash()},
+			expectedErr: nil,
+		},
+		{
+			name:        "Compare xxHash hash and io.Reader",
+			hash:        "29081d4d3fb56bc6",
+			input:       filepath.Join("testdata", "test.txt"),
+			isFile:      true,
+			opts:        []Option{WithXXHash()},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hashBytes, err := hex.DecodeString(tt.hash)
+			if err != nil {
+				t.Fatalf("hex.DecodeString() error = %v", err)
+			}
+
+			h := NewHash(tt.opts...)
+
+			var input any
+			input = tt.input
+			if tt.isFile {
+				if _, ok := tt.input.(string); ok {
+					i, ok := tt.input.(string)
+					if !ok {
+						t.Fatalf("input is not a string")
+					}
+
+					f, err := os.Open(filepath.Clean(i))
+					if err != nil {
+						t.Fatalf("os.Open() error = %v", err)
+					}
+					defer f.Close() //nolint:errcheck
+
+					input = f
+				}
+			}
+
+			err = h.Compare(hashBytes, input)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Hash.Compare() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Hash.Compare() error = %v", err)
+			}
+		})
+	}
+}
+
+type userHash struct{}
+
+func (u *userHash) GenHashFromString(_ string) ([]byte, error) {
+	return []byte{116, 101, 115, 116}, nil // "test"
+}
+
+func (u *userHash) GenHashFromIOReader(_ io.Reader) ([]byte, error) {
+	return []byte{116, 101, 115, 116}, nil // "test"
+}
+
+func (u *userHash) CmpHashAndString(hash []byte, s string) error {
+	return nil
+}
+
+func (u *userHash) CmpHashAndIOReader(hash []byte, r io.Reader) error {
+	return nil
+}
+
+func TestHash_GenerateWithPooledBuffer(t *testing.T) {
+	t.Parallel()
+
+	opts := [][]Option{
+		{WithSha256()},
+		{WithSha256(), WithBufferSize(16)},
+		{WithMd5(), WithBufferSize(1)},
+		{WithBlake3(), WithBufferSize(7)},
+	}
+
+	for _, opt := range opts {
+		h := NewHash(opt...)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 64; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				s := strings.Repeat(string(rune('a'+i%26)), 1024+i)
+				want, err := h.Generate(s)
+				if err != nil {
+					t.Errorf("Hash.Generate() error = %v", err)
+					return
+				}
+
+				// iotest.OneByteReader hides io.WriterTo so the pooled buffer is really used.
+				got, err := h.Generate(iotest.OneByteReader(strings.NewReader(s)))
+				if err != nil {
+					t.Errorf("Hash.Generate() error = %v", err)
+					return
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("Hash.Generate() = %x, want %x", got, want)
+				}
+
+				if err := h.Compare(want, iotest.OneByteReader(strings.NewReader(s))); err != nil {
+					t.Errorf("Hash.Compare() error = %v", err)
+				}
+			}(i)
+		}
+		wg.Wait()
+	}
+}
+
+// writerToReader is an io.Reader whose WriteTo records that it was called.
+type writerToReader struct {
+	io.Reader
+	called bool
+}
+
+func (w *writerToReader) WriteTo(dst io.Writer) (int64, error) {
+	w.called = true
+	return io.Copy(dst, struct{ io.Reader }{w.Reader})
+}
+
+func TestCopyBuffer(t *testing.T) {
+	t.Parallel()
+
+	want := sha256.Sum256([]byte("test"))
+	for _, src := range []io.Reader{
+		bytes.NewReader([]byte("test")),
+		bytes.NewBufferString("test"),
+		strings.NewReader("test"),
+		&writerToReader{Reader: strings.NewReader("test")},
+	} {
+		h := sha256.New()
+		if _, err := copyBuffer(h, src, 0); err != nil {
+			t.Fatalf("copyBuffer(%T) error = %v", src, err)
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+			t.Errorf("copyBuffer(%T) copied %x, want %x", src, got, want)
+		}
+		// Only in-memory readers may bypass the pooled buffer; WriteTo of other readers, such as *os.File, allocates.
+		if w, ok := src.(*writerToReader); ok && w.called {
+			t.Error("copyBuffer() called WriteTo of a reader that is not in memory")
+		}
+	}
+
+	if _, err := copyBuffer(shortWriter{}, iotest.OneByteReader(strings.NewReader("test")), 0); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("copyBuffer() error = %v, want %v", err, io.ErrShortWrite)
+	}
+}
+
+// shortWriter is an io.Writer that writes nothing and returns no error.
+type shortWriter struct{}
+
+func (shortWriter) Write([]byte) (int, error) {
+	return 0, nil
+}
+
+func BenchmarkHash_GenerateSmallReaders(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "small")
+	if err := os.WriteFile(path, []byte("small input for a small reader"), 0o600); err != nil {
+		b.Fatal(err)
+	}
+
+	h := NewHash(WithSha256())
+	benchmarks := []struct {
+		name string
+		gen  func(r io.Reader) ([]byte, error)
+	}{
+		{
+			name: "io.Copy",
+			gen: func(r io.Reader) ([]byte, error) {
+				h := sha256.New()
+				if _, err := io.Copy(h, r); err != nil {
+					return nil, err
+				}
+				return h.Sum(nil), nil
+			},
+		},
+		{
+			name: "pooled buffer",
+			gen: func(r io.Reader) ([]byte, error) {
+				return h.Generate(r)
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f, err := os.Open(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, err = bm.gen(f)
+				f.Close()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHash_GenerateBufferSize(b *testing.B) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 1024*1024) // 16MB
+	path := filepath.Join(b.TempDir(), "data")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, size := range []int{32 * 1024, 1024 * 1024} {
+		size := size
+		b.Run(fmt.Sprintf("%dKB", size/1024), func(b *testing.B) {
+			h := NewHash(WithSha256(), WithBufferSize(size))
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f, err := os.Open(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := h.Generate(f); err != nil {
+					b.Fatal(err)
+				}
+				f.Close()
+			}
+		})
+	}
+}
+
+func BenchmarkHash_GenerateBytesBuffer(b *testing.B) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), 64*1024) // 1MB
+
+	benchmarks := []struct {
+		name string
+		wrap func(buf *bytes.Buffer) io.Reader
+	}{
+		{
+			name: "WriterTo",
+			wrap: func(buf *bytes.Buffer) io.Reader { return buf },
+		},
+		{
+			// Wrapping the buffer hides io.WriterTo, so the content is copied through a copy buffer.
+			name: "copy buffer",
+			wrap: func(buf *bytes.Buffer) io.Reader { return struct{ io.Reader }{buf} },
+		},
+	}
+
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			h := NewHash(WithSha256())
+			b.SetBytes(int64(len(data)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := h.Generate(bm.wrap(bytes.NewBuffer(data))); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHash_GenerateFilesInSequence(b *testing.B) {
+	dir := b.TempDir()
+	files := make([]string, 16)
+	for i := range files {
+		files[i] = filepath.Join(dir, fmt.Sprintf("file%d", i))
+		if err := os.WriteFile(files[i], bytes.Repeat([]byte{byte(i)}, 256*1024), 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	h := NewHash(WithSha256())
+	benchmarks := []struct {
+		name string
+		gen  func(r io.Reader) ([]byte, error)
+	}{
+		{
+			name: "io.Copy",
+			gen: func(r io.Reader) ([]byte, error) {
+				h := sha256.New()
+				if _, err := io.Copy(h, r); err != nil {
+					return nil, err
+				}
+				return h.Sum(nil), nil
+			},
+		},
+		{
+			name: "pooled buffer",
+			gen: func(r io.Reader) ([]byte, error) {
+				return h.Generate(r)
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, name := range files {
+					f, err := os.Open(name)
+					if err != nil {
+						b.Fatal(err)
+					}
+					_, err = bm.gen(f)
+					f.Close()
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHash_GenerateWithNonce(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithSha256())
+
+	digestA, nonceA, err := h.GenerateWithNonce("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateWithNonce() error = %v", err)
+	}
+	digestB, nonceB, err := h.GenerateWithNonce("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateWithNonce() error = %v", err)
+	}
+
+	if len(nonceA) != NonceSize || len(nonceB) != NonceSize {
+		t.Fatalf("nonce length = %d, %d, want %d", len(nonceA), len(nonceB), NonceSize)
+	}
+	if bytes.Equal(nonceA, nonceB) {
+		t.Errorf("Hash.GenerateWithNonce() returned the same nonce twice: %x", nonceA)
+	}
+	if bytes.Equal(digestA, digestB) {
+		t.Errorf("Hash.GenerateWithNonce() returned the same digest twice: %x", digestA)
+	}
+
+	if err := h.CompareWithNonce(digestA, nonceA, "test"); err != nil {
+		t.Errorf("Hash.CompareWithNonce() error = %v", err)
+	}
+	if err := h.CompareWithNonce(digestB, nonceB, strings.NewReader("test")); err != nil {
+		t.Errorf("Hash.CompareWithNonce() error = %v", err)
+	}
+	if err := h.CompareWithNonce(digestA, nonceB, "test"); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.CompareWithNonce() error = %v, want %v", err, ErrHashMismatch)
+	}
+
+	want := sha256.Sum256(append(append([]byte{}, nonceA...), "test"...))
+	if !bytes.Equal(digestA, want[:]) {
+		t.Errorf("Hash.GenerateWithNonce() = %x, want %x", digestA, want)
+	}
+
+	if _, _, err := h.GenerateWithNonce(1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.GenerateWithNonce() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+
+	t.Run("retried inputs", func(t *testing.T) {
+		t.Parallel()
+
+		var opened, closed int
+		open := ReaderFunc(func() (io.Reader, error) {
+			opened++
+			var r io.Reader = strings.NewReader("test")
+			if opened == 1 {
+				r = io.MultiReader(strings.NewReader("te"), iotest.ErrReader(errFlakyRead))
+			}
+			return &closeCounter{Reader: r, closed: &closed}, nil
+		})
+
+		h := NewHash(WithSha256(), WithRetry(1))
+		for _, input := range []any{
+			open,
+			&flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2},
+			[]byte("test"),
+		} {
+			digest, nonce, err := h.GenerateWithNonce(input)
+			if err != nil {
+				t.Fatalf("Hash.GenerateWithNonce(%T) error = %v", input, err)
+			}
+			want := sha256.Sum256(append(append([]byte{}, nonce...), "test"...))
+			if !bytes.Equal(digest, want[:]) {
+				t.Errorf("Hash.GenerateWithNonce(%T) = %x, want %x", input, digest, want)
+			}
+		}
+		if closed != opened {
+			t.Errorf("%d readers closed, want %d", closed, opened)
+		}
+	})
+}
+
+func TestWithCompactSizePrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		length int
+		prefix []byte
+	}{
+		{name: "252 bytes", length: 252, prefix: []byte{0xfc}},
+		{name: "253 bytes", length: 253, prefix: []byte{0xfd, 0xfd, 0x00}},
+		{name: "65535 bytes", length: 65535, prefix: []byte{0xfd, 0xff, 0xff}},
+		{name: "65536 bytes", length: 65536, prefix: []byte{0xfe, 0x00, 0x00, 0x01, 0x00}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload := strings.Repeat("a", tt.length)
+			want := sha256.Sum256(append(append([]byte{}, tt.prefix...), payload...))
+
+			h := NewHash(WithSha256(), WithCompactSizePrefix())
+			got, err := h.Generate(payload)
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, want[:]) {
+				t.Errorf("Hash.Generate() = %x, want %x", got, want)
+			}
+
+			got, err = h.Generate(strings.NewReader(payload))
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, want[:]) {
+				t.Errorf("Hash.Generate() = %x, want %x", got, want)
+			}
+
+			if err := h.Compare(want[:], payload); err != nil {
+				t.Errorf("Hash.Compare() error = %v", err)
+			}
+		})
+	}
+
+	if got := compactSize(1 << 32); !bytes.Equal(got, []byte{0xff, 0, 0, 0, 0, 1, 0, 0, 0}) {
+		t.Errorf("compactSize() = %x", got)
+	}
+}
+
+func TestWithLengthPrefix(t *testing.T) {
+	t.Parallel()
+
+	want := sha256.Sum256([]byte("\x00\x00\x00\x00\x00\x00\x00\x04test"))
+	h := NewHash(WithSha256(), WithLengthPrefix())
+	for _, input := range []func() any{
+		func() any { return "test" },
+		func() any { return []byte("test") },
+		func() any { return strings.NewReader("test") },
+	} {
+		got, err := h.Generate(input())
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.Generate(%T) = %x, want %x", input(), got, want)
+		}
+		if err := h.Compare(want[:], input()); err != nil {
+			t.Errorf("Hash.Compare(%T) error = %v", input(), err)
+		}
+	}
+
+	// The whole input is framed once, so fields concatenated into one input are not separated.
+	a, err := h.Generate("ab" + "c")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	b, err := h.Generate("a" + "bc")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if want := sha256.Sum256([]byte("\x00\x00\x00\x00\x00\x00\x00\x03abc")); !bytes.Equal(a, want[:]) || !bytes.Equal(b, want[:]) {
+		t.Errorf("Hash.Generate() = %x and %x, want %x", a, b, want)
+	}
+}
+
+func TestWithDomainSeparation(t *testing.T) {
+	t.Parallel()
+
+	want := sha256.Sum256([]byte("\x00\x00\x00\x00\x00\x00\x00\x05loginpayload"))
+	login := NewHash(WithSha256(), WithDomainSeparation("login"))
+	for _, input := range []func() any{
+		func() any { return "payload" },
+		func() any { return []byte("payload") },
+		func() any { return strings.NewReader("payload") },
+	} {
+		got, err := login.Generate(input())
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.Generate(%T) = %x, want %x", input(), got, want)
+		}
+		if err := login.Compare(want[:], input()); err != nil {
+			t.Errorf("Hash.Compare(%T) error = %v", input(), err)
+		}
+	}
+
+	signup, err := NewHash(WithSha256(), WithDomainSeparation("signup")).Generate("payload")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if bytes.Equal(signup, want[:]) {
+		t.Errorf("contexts \"login\" and \"signup\" yield the same hash %x", signup)
+	}
+	if err := login.Compare(signup, "payload"); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+	}
+}
+
+func TestWithHexDecodeReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Hash hex-decoded file", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open(filepath.Join("testdata", "hex.txt"))
+		if err != nil {
+			t.Fatalf("os.Open() error = %v", err)
+		}
+		defer f.Close() //nolint:errcheck
+
+		got, err := NewHash(WithSha256(), WithHexDecodeReader()).Generate(f)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+
+		want := sha256.Sum256([]byte("test"))
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.Generate() = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("Malformed hex", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewHash(WithSha256(), WithHexDecodeReader()).Generate(strings.NewReader("7465zz74"))
+		var invalidByte hex.InvalidByteError
+		if !errors.As(err, &invalidByte) {
+			t.Errorf("Hash.Generate() error = %v, want hex.InvalidByteError", err)
+		}
+	})
+
+	t.Run("Odd length hex", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewHash(WithSha256(), WithHexDecodeReader()).Generate(strings.NewReader("746"))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestWithTimeBucket(t *testing.T) {
+	t.Parallel()
+
+	var now time.Time
+	clock := func() time.Time { return now }
+	h := NewHash(WithSha256(), WithTimeBucket(time.Hour, clock))
+
+	now = time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+	first, err := h.Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	now = time.Date(2024, 1, 1, 10, 59, 59, 0, time.UTC)
+	sameBucket, err := h.Generate(strings.NewReader("test"))
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if !bytes.Equal(first, sameBucket) {
+		t.Errorf("digests in the same bucket differ: %x, %x", first, sameBucket)
+	}
+	if err := h.Compare(first, "test"); err != nil {
+		t.Errorf("Hash.Compare() error = %v", err)
+	}
+
+	now = time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+	nextBucket, err := h.Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if bytes.Equal(first, nextBucket) {
+		t.Errorf("digests in different buckets are equal: %x", first)
+	}
+	if err := h.Compare(first, "test"); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+	}
+
+	for _, d := range []time.Duration{0, -time.Hour} {
+		if _, err := NewHash(WithSha256(), WithTimeBucket(d, nil)).Generate("test"); !errors.Is(err, ErrInvalidTimeBucket) {
+			t.Errorf("Hash.Generate() with a bucket of %v error = %v, want %v", d, err, ErrInvalidTimeBucket)
+		}
+	}
+}
+
+func TestHash_BufferAndHash(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join("testdata", "test.txt")
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open() error = %v", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	rs, digest, err := NewHash().BufferAndHash(f)
+	if err != nil {
+		t.Fatalf("Hash.BufferAndHash() error = %v", err)
+	}
+
+	if got := hex.EncodeToString(digest); got != "7b4bc55c9a1295ecbd2b77a636565f27" {
+		t.Errorf("Hash.BufferAndHash() digest = %s, want %s", got, "7b4bc55c9a1295ecbd2b77a636565f27")
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		got, err := io.ReadAll(rs)
+		if err != nil {
+			t.Fatalf("io.ReadAll() error = %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("buffered content = %q, want %q", got, want)
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			t.Fatalf("Seek() error = %v", err)
+		}
+	}
+}
+
+func TestWithConcatenate(t *testing.T) {
+	t.Parallel()
+
+	md5sum, err := hex.DecodeString("098f6bcd4621d373cade4e832627b4f6")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() error = %v", err)
+	}
+	sha1sum, err := hex.DecodeString("a94a8fe5ccb19ba61c4c0873d391e987982fbbd3")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() error = %v", err)
+	}
+	md5sha1 := append(append([]byte{}, md5sum...), sha1sum...)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		input    func() any
+		expected []byte
+	}{
+		{
+			name:     "string",
+			opts:     []Option{WithMd5(), WithSha1()},
+			input:    func() any { return "test" },
+			expected: md5sha1,
+		},
+		{
+			name:     "io.Reader",
+			opts:     []Option{WithMd5(), WithSha1()},
+			input:    func() any { return strings.NewReader("test") },
+			expected: md5sha1,
+		},
+		{
+			name:     "io.Reader with a non-streaming algorithm",
+			opts:     []Option{WithMd5(), WithSha1(), WithUserDifinedAlgorithm(&userHash{})},
+			input:    func() any { return strings.NewReader("test") },
+			expected: append(append([]byte{}, md5sha1...), "test"...),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(WithConcatenate(tt.opts...))
+			got, err := h.Generate(tt.input())
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.expected) {
+				t.Errorf("Hash.Generate() = %x, want %x", got, tt.expected)
+			}
+
+			if err := h.Compare(tt.expected, tt.input()); err != nil {
+				t.Errorf("Hash.Compare() error = %v", err)
+			}
+			flipped := bytes.Clone(tt.expected)
+			flipped[0] ^= 0xff
+			if err := h.Compare(flipped, tt.input()); !errors.Is(err, ErrHashMismatch) {
+				t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+			}
+		})
+	}
+}
+
+func TestWithPhash_InvalidImage(t *testing.T) {
+	t.Parallel()
+
+	jpg, err := os.ReadFile(filepath.Join("testdata", "test.jpg"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Truncated JPEG", input: jpg[:len(jpg)/2]},
+		{name: "JPEG header followed by garbage", input: append(append([]byte{}, jpg[:4]...), "garbage"...)},
+		{name: "Empty input", input: []byte{}},
+		{name: "Text file", input: []byte("test")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(WithPhash())
+			if _, err := h.Generate(bytes.NewReader(tt.input)); !errors.Is(err, ErrInvalidImage) {
+				t.Errorf("Hash.Generate() error = %v, want %v", err, ErrInvalidImage)
+			}
+			if err := h.Compare(make([]byte, 8), bytes.NewReader(tt.input)); !errors.Is(err, ErrInvalidImage) {
+				t.Errorf("Hash.Compare() error = %v, want %v", err, ErrInvalidImage)
+			}
+		})
+	}
+}
+
+func TestWithProgressBar(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Known total", func(t *testing.T) {
+		t.Parallel()
+
+		data := strings.Repeat("a", 1000)
+		var buf bytes.Buffer
+		h := NewHash(WithSha256(), WithProgressBar(&buf, int64(len(data))))
+
+		// iotest.OneByteReader makes the reader report progress one byte at a time.
+		if _, err := h.Generate(iotest.OneByteReader(strings.NewReader(data))); err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+
+		out := buf.String()
+		if got := strings.Count(out, "\r"); got < 100 {
+			t.Errorf("progress updates = %d, want at least 100", got)
+		}
+		if !strings.Contains(out, " 50% (500/1000 bytes)") {
+			t.Errorf("progress output does not contain 50%%: %q", out)
+		}
+		if !strings.HasSuffix(out, "100% (1000/1000 bytes)\n") {
+			t.Errorf("progress output does not end with 100%%: %q", out[len(out)-80:])
+		}
+	})
+
+	t.Run("Unknown total", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		h := NewHash(WithSha256(), WithProgressBar(&buf, 0))
+		if _, err := h.Generate(strings.NewReader("test")); err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if got := buf.String(); !strings.HasSuffix(got, "\r4 bytes\n") {
+			t.Errorf("progress output = %q, want suffix %q", got, "\r4 bytes\n")
+		}
+	})
+
+	t.Run("String input", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		h := NewHash(WithSha256(), WithProgressBar(&buf, 4))
+		if _, err := h.Generate("test"); err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("progress output = %q, want empty", buf.String())
+		}
+	})
+}
+
+func TestHash_CompareReturning(t *testing.T) {
+	t.Parallel()
+
+	want, err := hex.DecodeString("098f6bcd4621d373cade4e832627b4f6")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() error = %v", err)
+	}
+	h := NewHash()
+
+	got, err := h.CompareReturning(want, "test")
+	if err != nil {
+		t.Errorf("Hash.CompareReturning() error = %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Hash.CompareReturning() = %x, want %x", got, want)
+	}
+
+	got, err = h.CompareReturning(want, strings.NewReader("mismatch"))
+	if !errors.Is(err, ErrHashMismatch) || err.Error() != "md5: hash mismatch" {
+		t.Errorf("Hash.CompareReturning() error = %v, want %v prefixed with the algorithm", err, ErrHashMismatch)
+	}
+	if mismatch := md5Hex(t, "mismatch"); hex.EncodeToString(got) != mismatch {
+		t.Errorf("Hash.CompareReturning() = %x, want %s", got, mismatch)
+	}
+
+	got, err = h.CompareReturning(want, 1)
+	if !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.CompareReturning() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+	if got != nil {
+		t.Errorf("Hash.CompareReturning() = %x, want nil", got)
+	}
+
+	// The length is checked before the input is read.
+	got, err = h.CompareReturning(want[:4], iotest.ErrReader(errFlakyRead))
+	if !errors.Is(err, ErrInvalidHashLength) {
+		t.Errorf("Hash.CompareReturning() error = %v, want %v", err, ErrInvalidHashLength)
+	}
+	if got != nil {
+		t.Errorf("Hash.CompareReturning() = %x, want nil", got)
+	}
+
+	t.Run("bcrypt", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithBcrypt(bcrypt.MinCost))
+		hash, err := h.Generate("password")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		got, err := h.CompareReturning(hash, "password")
+		if !errors.Is(err, ErrNotReproducible) {
+			t.Errorf("Hash.CompareReturning() error = %v, want %v", err, ErrNotReproducible)
+		}
+		if got != nil {
+			t.Errorf("Hash.CompareReturning() = %s, want nil", got)
+		}
+		if err := h.Compare(hash, "password"); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+	})
+
+	t.Run("wrapped algorithm and io.Reader input", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithSalt([]byte("salt"), SaltPrefix))
+		want, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		got, err := h.CompareReturning(want, strings.NewReader("test"))
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("Hash.CompareReturning() = %x, %v, want %x", got, err, want)
+		}
+		got, err = h.CompareReturning(want, strings.NewReader("mismatch"))
+		if !errors.Is(err, ErrHashMismatch) || got == nil || bytes.Equal(got, want) {
+			t.Errorf("Hash.CompareReturning() = %x, %v, want the hash of the input and %v", got, err, ErrHashMismatch)
+		}
+	})
+}
+
+func md5Hex(t *testing.T, s string) string {
+	t.Helper()
+
+	sum := md5.Sum([]byte(s)) //nolint:gosec
+	return hex.EncodeToString(sum[:])
+}
+
+func TestWithBcrypt(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithBcrypt(bcrypt.MinCost))
+
+	hashA, err := h.Generate("password")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	hashB, err := h.Generate("password")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if bytes.Equal(hashA, hashB) {
+		t.Errorf("bcrypt hashes of the same password are equal: %s", hashA)
+	}
+
+	for _, hash := range [][]byte{hashA, hashB} {
+		if err := h.Compare(hash, "password"); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if err := h.Compare(hash, "wrong password"); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+	}
+
+	if _, err := h.Generate(strings.NewReader("password")); !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("Hash.Generate() error = %v, want %v", err, ErrStreamingNotSupported)
+	}
+	if err := h.Compare(hashA, strings.NewReader("password")); !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("Hash.Compare() error = %v, want %v", err, ErrStreamingNotSupported)
+	}
+	if err := h.Compare([]byte("not a bcrypt hash"), "password"); err == nil || errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.Compare() error = %v, want a malformed hash error", err)
+	}
+}
+
+func TestHash_IsLengthExtendable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected bool
+	}{
+		{name: "md5", opts: []Option{WithMd5()}, expected: true},
+		{name: "sha1", opts: []Option{WithSha1()}, expected: true},
+		{name: "sha256", opts: []Option{WithSha256()}, expected: true},
+		{name: "sha512", opts: []Option{WithSha512()}, expected: true},
+		{name: "ripemd160", opts: []Option{WithRipemd160()}, expected: true},
+		{name: "sha256 with a prefix", opts: []Option{WithSha256(), WithCompactSizePrefix()}, expected: true},
+		{name: "truncated sha256", opts: []Option{WithSha256(), WithTruncate(16)}, expected: false},
+		{name: "truncated sha256 with a prefix", opts: []Option{WithSha256(), WithTruncate(16), WithCompactSizePrefix()}, expected: false},
+		{name: "sha256 truncated to its full length", opts: []Option{WithSha256(), WithTruncate(32)}, expected: true},
+		{name: "blake3", opts: []Option{WithBlake3()}, expected: false},
+		{name: "user-defined", opts: []Option{WithUserDifinedAlgorithm(&userHash{})}, expected: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewHash(tt.opts...).IsLengthExtendable(); got != tt.expected {
+				t.Errorf("Hash.IsLengthExtendable() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHash_HardenedMAC(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("secret key")
+	message := []byte("test")
+
+	tests := []struct {
+		name string
+		opts []Option
+		hash func() hash.Hash
+	}{
+		{name: "md5", opts: []Option{WithMd5()}, hash: md5.New},
+		{name: "sha256", opts: []Option{WithSha256()}, hash: sha256.New},
+		{name: "sha512", opts: []Option{WithSha512()}, hash: sha512.New},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHash(tt.opts...).HardenedMAC(key, message)
+			if err != nil {
+				t.Fatalf("Hash.HardenedMAC() error = %v", err)
+			}
+
+			mac := hmac.New(tt.hash, key)
+			mac.Write(message) //nolint:errcheck
+			if want := mac.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Hash.HardenedMAC() = %x, want %x", got, want)
+			}
+		})
+	}
+
+	if _, err := NewHash(WithPhash()).HardenedMAC(key, message); !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("Hash.HardenedMAC() error = %v, want %v", err, ErrStreamingNotSupported)
+	}
+}
+
+func TestHash_GenerateWithHumanSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		input             func() any
+		expectedHumanSize string
+		expectedSize      int64
+	}{
+		{
+			name:              "string",
+			input:             func() any { return "test" },
+			expectedHumanSize: "4 B",
+			expectedSize:      4,
+		},
+		{
+			name:              "io.Reader",
+			input:             func() any { return strings.NewReader("test") },
+			expectedHumanSize: "4 B",
+			expectedSize:      4,
+		},
+		{
+			name:              "KiB",
+			input:             func() any { return bytes.NewReader(make([]byte, 1536)) },
+			expectedHumanSize: "1.5 KiB",
+			expectedSize:      1536,
+		},
+		{
+			name:              "MiB",
+			input:             func() any { return bytes.NewReader(make([]byte, 1258291)) },
+			expectedHumanSize: "1.2 MiB",
+			expectedSize:      1258291,
+		},
+		{
+			name:              "ReaderFunc",
+			input:             func() any { return ReaderFunc(func() (io.Reader, error) { return strings.NewReader("test"), nil }) },
+			expectedHumanSize: "4 B",
+			expectedSize:      4,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(WithSha256())
+			digest, humanSize, size, err := h.GenerateWithHumanSize(tt.input())
+			if err != nil {
+				t.Fatalf("Hash.GenerateWithHumanSize() error = %v", err)
+			}
+			if humanSize != tt.expectedHumanSize {
+				t.Errorf("Hash.GenerateWithHumanSize() humanSize = %s, want %s", humanSize, tt.expectedHumanSize)
+			}
+			if size != tt.expectedSize {
+				t.Errorf("Hash.GenerateWithHumanSize() size = %d, want %d", size, tt.expectedSize)
+			}
+
+			want, err := h.Generate(tt.input())
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(digest, want) {
+				t.Errorf("Hash.GenerateWithHumanSize() digest = %x, want %x", digest, want)
+			}
+		})
+	}
+
+	if got := formatIECSize(1 << 40); got != "1.0 TiB" {
+		t.Errorf("formatIECSize() = %s, want %s", got, "1.0 TiB")
+	}
+
+	t.Run("retried seekable reader", func(t *testing.T) {
+		t.Parallel()
+
+		want := sha256.Sum256([]byte("test"))
+		h := NewHash(WithSha256(), WithRetry(1))
+		digest, _, size, err := h.GenerateWithHumanSize(&flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2})
+		if err != nil {
+			t.Fatalf("Hash.GenerateWithHumanSize() error = %v", err)
+		}
+		if !bytes.Equal(digest, want[:]) {
+			t.Errorf("Hash.GenerateWithHumanSize() digest = %x, want %x", digest, want)
+		}
+		if size != 4 {
+			t.Errorf("Hash.GenerateWithHumanSize() size = %d, want 4", size)
+		}
+	})
+
+	t.Run("retried ReaderFunc", func(t *testing.T) {
+		t.Parallel()
+
+		want := sha256.Sum256([]byte("test"))
+		var opened, closed int
+		open := ReaderFunc(func() (io.Reader, error) {
+			opened++
+			var r io.Reader = strings.NewReader("test")
+			if opened == 1 {
+				r = io.MultiReader(strings.NewReader("te"), iotest.ErrReader(errFlakyRead))
+			}
+			return &closeCounter{Reader: r, closed: &closed}, nil
+		})
+		digest, _, size, err := NewHash(WithSha256(), WithRetry(1)).GenerateWithHumanSize(open)
+		if err != nil {
+			t.Fatalf("Hash.GenerateWithHumanSize() error = %v", err)
+		}
+		if !bytes.Equal(digest, want[:]) {
+			t.Errorf("Hash.GenerateWithHumanSize() digest = %x, want %x", digest, want)
+		}
+		if size != 4 {
+			t.Errorf("Hash.GenerateWithHumanSize() size = %d, want 4", size)
+		}
+		if closed != opened {
+			t.Errorf("%d readers closed, want %d", closed, opened)
+		}
+	})
+}
+
+// flakyReader is a seekable reader that fails once after reading failAfter bytes.
+type flakyReader struct {
+	r         *bytes.Reader
+	failAfter int64
+	failed    bool
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	pos := f.r.Size() - int64(f.r.Len())
+	if !f.failed && pos >= f.failAfter {
+		f.failed = true
+		return 0, errFlakyRead
+	}
+	return f.r.Read(p[:1])
+}
+
+func (f *flakyReader) Seek(offset int64, whence int) (int64, error) {
+	return f.r.Seek(offset, whence)
+}
+
+var errFlakyRead = errors.New("transient read error")
+
+// closeCounter is an io.ReadCloser that counts how many times it is closed.
+type closeCounter struct {
+	io.Reader
+	closed *int
+}
+
+func (c *closeCounter) Close() error {
+	*c.closed++
+	return nil
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	want, err := hex.DecodeString("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+	if err != nil {
+		t.Fatalf("hex.DecodeString() error = %v", err)
+	}
+
+	t.Run("Seekable reader fails once", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithRetry(1))
+		got, err := h.Generate(&flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2})
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Hash.Generate() = %x, want %x", got, want)
+		}
+
+		if err := h.Compare(want, &flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2}); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+	})
+
+	t.Run("ReaderFunc fails once", func(t *testing.T) {
+		t.Parallel()
+
+		opened := 0
+		open := ReaderFunc(func() (io.Reader, error) {
+			opened++
+			if opened == 1 {
+				return iotest.TimeoutReader(strings.NewReader("test")), nil
+			}
+			return strings.NewReader("test"), nil
+		})
+
+		got, err := NewHash(WithSha256(), WithRetry(3)).Generate(open)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Hash.Generate() = %x, want %x", got, want)
+		}
+		if opened != 2 {
+			t.Errorf("ReaderFunc called %d times, want 2", opened)
+		}
+	})
+
+	t.Run("Without retry", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewHash(WithSha256()).Generate(&flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2})
+		if !errors.Is(err, errFlakyRead) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, errFlakyRead)
+		}
+	})
+
+	t.Run("Non-seekable reader fails immediately", func(t *testing.T) {
+		t.Parallel()
+
+		r := iotest.TimeoutReader(strings.NewReader("test"))
+		_, err := NewHash(WithSha256(), WithRetry(3)).Generate(r)
+		if !errors.Is(err, iotest.ErrTimeout) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, iotest.ErrTimeout)
+		}
+	})
+
+	t.Run("Mismatch is not retried", func(t *testing.T) {
+		t.Parallel()
+
+		opened := 0
+		open := ReaderFunc(func() (io.Reader, error) {
+			opened++
+			return strings.NewReader("mismatch"), nil
+		})
+		if err := NewHash(WithSha256(), WithRetry(3)).Compare(want, open); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+		if opened != 1 {
+			t.Errorf("ReaderFunc called %d times, want 1", opened)
+		}
+	})
+
+	t.Run("nil reader", func(t *testing.T) {
+		t.Parallel()
+
+		open := ReaderFunc(func() (io.Reader, error) { return nil, nil })
+		h := NewHash(WithSha256())
+		if _, err := h.Generate(open); !errors.Is(err, ErrNilReader) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrNilReader)
+		}
+		if _, _, _, err := h.GenerateWithHumanSize(open); !errors.Is(err, ErrNilReader) {
+			t.Errorf("Hash.GenerateWithHumanSize() error = %v, want %v", err, ErrNilReader)
+		}
+		if _, err := h.GenerateWithProgress(open, func(int64) {}); !errors.Is(err, ErrNilReader) {
+			t.Errorf("Hash.GenerateWithProgress() error = %v, want %v", err, ErrNilReader)
+		}
+		if err := NewHash(WithSha256(), WithRetry(1)).Compare(want, open); !errors.Is(err, ErrNilReader) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrNilReader)
+		}
+	})
+}
+
+func TestHash_ErrorIncludesAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	readErr := errors.New("read failed")
+	tests := []struct {
+		name    string
+		err     func() error
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "generate phash from string",
+			err:     func() error { _, err := NewHash(WithPhash()).Generate("test"); return err },
+			wantErr: ErrPhashNotSupportedString,
+			wantMsg: "phash: phash does not support string input",
+		},
+		{
+			name:    "compare phash and string",
+			err:     func() error { return NewHash(WithPhash()).Compare(make([]byte, phashSize), "test") },
+			wantErr: ErrPhashNotSupportedString,
+			wantMsg: "phash: phash does not support string input",
+		},
+		{
+			name:    "compare mismatch",
+			err:     func() error { return NewHash(WithSha256()).Compare(make([]byte, 32), "test") },
+			wantErr: ErrHashMismatch,
+			wantMsg: "sha256: hash mismatch",
+		},
+		{
+			name:    "generate from failing reader",
+			err:     func() error { _, err := NewHash(WithSha1()).Generate(iotest.ErrReader(readErr)); return err },
+			wantErr: readErr,
+			wantMsg: "sha1: read failed",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.err()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil || err.Error() != tt.wantMsg {
+				t.Errorf("error message = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHash_GenerateHex(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewHash().GenerateHex("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateHex() error = %v", err)
+	}
+	if want := "098f6bcd4621d373cade4e832627b4f6"; got != want {
+		t.Errorf("Hash.GenerateHex() = %s, want %s", got, want)
+	}
+
+	if _, err := NewHash().GenerateHex(1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.GenerateHex() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+	if _, err := NewHash(WithPhash()).GenerateHex("test"); !errors.Is(err, ErrPhashNotSupportedString) {
+		t.Errorf("Hash.GenerateHex() error = %v, want %v", err, ErrPhashNotSupportedString)
+	}
+}
+
+func TestHash_GenerateBase64(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		generate func(h *Hash, input any) (string, error)
+		expected string
+	}{
+		{name: "md5 base64", generate: (*Hash).GenerateBase64, expected: "CY9rzUYh03PK3k6DJie09g=="},
+		{name: "md5 base64url", generate: (*Hash).GenerateBase64URL, expected: "CY9rzUYh03PK3k6DJie09g"},
+		{name: "sha256 base64", opts: []Option{WithSha256()}, generate: (*Hash).GenerateBase64, expected: "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="},
+		{name: "sha256 base64url", opts: []Option{WithSha256()}, generate: (*Hash).GenerateBase64URL, expected: "n4bQgYhMfWWaL-qgxVrQFaO_TxsrC4Is0V1sFbDwCgg"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			got, err := tt.generate(h, "test")
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("generate() = %s, want %s", got, tt.expected)
+			}
+			if _, err := tt.generate(h, 1); !errors.Is(err, ErrUnsupportedInputType) {
+				t.Errorf("generate() error = %v, want %v", err, ErrUnsupportedInputType)
+			}
+		})
+	}
+}
+
+func TestHash_GenerateString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{name: "default", expected: "098f6bcd4621d373cade4e832627b4f6"},
+		{name: "hex", opts: []Option{WithEncoding(EncodingHex)}, expected: "098f6bcd4621d373cade4e832627b4f6"},
+		{name: "base64", opts: []Option{WithEncoding(EncodingBase64)}, expected: "CY9rzUYh03PK3k6DJie09g=="},
+		{name: "base64url", opts: []Option{WithEncoding(EncodingBase64URL)}, expected: "CY9rzUYh03PK3k6DJie09g"},
+		{name: "raw", opts: []Option{WithEncoding(EncodingRaw)}, expected: "\x09\x8f\x6b\xcd\x46\x21\xd3\x73\xca\xde\x4e\x83\x26\x27\xb4\xf6"},
+		{name: "sha256 base64url", opts: []Option{WithSha256(), WithEncoding(EncodingBase64URL)}, expected: "n4bQgYhMfWWaL-qgxVrQFaO_TxsrC4Is0V1sFbDwCgg"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			got, err := h.GenerateString("test")
+			if err != nil {
+				t.Fatalf("Hash.GenerateString() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Hash.GenerateString() = %q, want %q", got, tt.expected)
+			}
+			if _, err := h.GenerateString(1); !errors.Is(err, ErrUnsupportedInputType) {
+				t.Errorf("Hash.GenerateString() error = %v, want %v", err, ErrUnsupportedInputType)
+			}
+		})
+	}
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewHash(WithEncoding(Encoding(-1))).GenerateString("test"); !errors.Is(err, ErrUnsupportedEncoding) {
+			t.Errorf("Hash.GenerateString() error = %v, want %v", err, ErrUnsupportedEncoding)
+		}
+	})
+}
+
+func TestHash_GenerateTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		encoding string
+		expected string
+	}{
+		{encoding: "hex", expected: "098f6bcd4621d373cade4e832627b4f6"},
+		{encoding: "base64", expected: "CY9rzUYh03PK3k6DJie09g=="},
+		{encoding: "raw", expected: string([]byte{0x09, 0x8f, 0x6b, 0xcd, 0x46, 0x21, 0xd3, 0x73, 0xca, 0xde, 0x4e, 0x83, 0x26, 0x27, 0xb4, 0xf6})},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.encoding, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			buf.WriteString("prefix:")
+			if err := NewHash().GenerateTo(&buf, strings.NewReader("test"), tt.encoding); err != nil {
+				t.Fatalf("Hash.GenerateTo() error = %v", err)
+			}
+			if got, want := buf.String(), "prefix:"+tt.expected; got != want {
+				t.Errorf("Hash.GenerateTo() wrote %q, want %q", got, want)
+			}
+		})
+	}
+
+	t.Run("unsupported encoding", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		r := strings.NewReader("test")
+		if err := NewHash().GenerateTo(&buf, r, "base58"); !errors.Is(err, ErrUnsupportedEncoding) {
+			t.Errorf("Hash.GenerateTo() error = %v, want %v", err, ErrUnsupportedEncoding)
+		}
+		if buf.Len() != 0 || r.Len() != 4 {
+			t.Errorf("Hash.GenerateTo() wrote %q and read %d bytes, want nothing", buf.String(), 4-r.Len())
+		}
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		t.Parallel()
+
+		if err := NewHash().GenerateTo(io.Discard, 1, "hex"); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.GenerateTo() error = %v, want %v", err, ErrUnsupportedInputType)
+		}
+	})
+}
+
+func TestHash_GenerateBase36(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewHash().GenerateBase36("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateBase36() error = %v", err)
+	}
+	if want := "0KDISMNX5MOYU6Q6PZT8TQDPY"; got != want {
+		t.Errorf("Hash.GenerateBase36() = %s, want %s", got, want)
+	}
+
+	n, ok := new(big.Int).SetString(got, 36)
+	if !ok {
+		t.Fatalf("big.Int.SetString(%s) failed", got)
+	}
+	digest := n.FillBytes(make([]byte, md5.Size))
+	if err := NewHash().Compare(digest, "test"); err != nil {
+		t.Errorf("decoded base36 digest %x does not match: %v", digest, err)
+	}
+
+	if got := encodeBase36([]byte{0, 0}); got != "0000" {
+		t.Errorf("encodeBase36() = %s, want %s", got, "0000")
+	}
+	if _, err := NewHash().GenerateBase36(1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.GenerateBase36() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+}
+
+func TestWithTrimSpace(t *testing.T) {
+	t.Parallel()
+
+	want, err := NewHash(WithSha256()).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	h := NewHash(WithSha256(), WithTrimSpace())
+	for _, input := range []any{"  test  ", "\ttest\n", strings.NewReader("  test\r\n")} {
+		got, err := h.Generate(input)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Hash.Generate(%q) = %x, want %x", input, got, want)
+		}
+	}
+	if err := h.Compare(want, "  test  "); err != nil {
+		t.Errorf("Hash.Compare() error = %v", err)
+	}
+
+	untrimmed, err := NewHash(WithSha256()).Generate("  test  ")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if bytes.Equal(untrimmed, want) {
+		t.Errorf("digest without WithTrimSpace must differ: %x", untrimmed)
+	}
+}
+
+func TestDigest_String(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewHash().GenerateDigest("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateDigest() error = %v", err)
+	}
+
+	const want = "098f6bcd4621d373cade4e832627b4f6"
+	if got := d.Hex(); got != want {
+		t.Errorf("Digest.Hex() = %s, want %s", got, want)
+	}
+	if got := d.String(); got != want {
+		t.Errorf("Digest.String() = %s, want %s", got, want)
+	}
+	if got := fmt.Sprint(d); got != want {
+		t.Errorf("fmt.Sprint(Digest) = %s, want %s", got, want)
+	}
+}
+
+func TestHash_GenerateDigest(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithSha256())
+	d, err := h.GenerateDigest("test")
+	if err != nil {
+		t.Fatalf("Hash.GenerateDigest() error = %v", err)
+	}
+
+	if got, want := d.Hex(), "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"; got != want {
+		t.Errorf("Digest.Hex() = %s, want %s", got, want)
+	}
+	if got, want := d.Base64(), "n4bQgYhMfWWaL+qgxVrQFaO/TxsrC4Is0V1sFbDwCgg="; got != want {
+		t.Errorf("Digest.Base64() = %s, want %s", got, want)
+	}
+	if got, want := d.Base32(), "T6DNBAMIJR6WLGRP5KQMKWWQCWR36TY3FMFYELGRLVWBLMHQBIEA===="; got != want {
+		t.Errorf("Digest.Base32() = %s, want %s", got, want)
+	}
+	want := sha256.Sum256([]byte("test"))
+	if !bytes.Equal(d.Bytes(), want[:]) {
+		t.Errorf("Digest.Bytes() = %x, want %x", d.Bytes(), want)
+	}
+
+	same, err := h.GenerateDigest(strings.NewReader("test"))
+	if err != nil {
+		t.Fatalf("Hash.GenerateDigest() error = %v", err)
+	}
+	if !d.Equal(same) {
+		t.Errorf("Digest.Equal() = false, want true")
+	}
+	other, err := h.GenerateDigest("other")
+	if err != nil {
+		t.Fatalf("Hash.GenerateDigest() error = %v", err)
+	}
+	if d.Equal(other) || d.Equal(d[:8]) {
+		t.Errorf("Digest.Equal() = true, want false")
+	}
+
+	if _, err := h.GenerateDigest(1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.GenerateDigest() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+}
+
+func TestHash_GenerateTarContent(t *testing.T) {
+	t.Parallel()
+
+	type file struct {
+		name    string
+		content string
+		modTime time.Time
+	}
+	newTar := func(t *testing.T, files []file) *bytes.Buffer {
+		t.Helper()
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, f := range files {
+			hdr := &tar.Header{
+				Name:    f.name,
+				Mode:    0o644,
+				Size:    int64(len(f.content)),
+				ModTime: f.modTime,
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				t.Fatalf("tar.Writer.WriteHeader() error = %v", err)
+			}
+			if _, err := tw.Write([]byte(f.content)); err != nil {
+				t.Fatalf("tar.Writer.Write() error = %v", err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatalf("tar.Writer.Close() error = %v", err)
+		}
+		return &buf
+	}
+
+	older := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := NewHash(WithSha256())
+
+	a, err := h.GenerateTarContent(newTar(t, []file{
+		{name: "a.txt", content: "aaa", modTime: older},
+		{name: "dir/b.txt", content: "bbb", modTime: older},
+	}))
+	if err != nil {
+		t.Fatalf("Hash.GenerateTarContent() error = %v", err)
+	}
+
+	b, err := h.GenerateTarContent(newTar(t, []file{
+		{name: "./dir/b.txt", content: "bbb", modTime: newer},
+		{name: "a.txt", content: "aaa", modTime: newer},
+	}))
+	if err != nil {
+		t.Fatalf("Hash.GenerateTarContent() error = %v", err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Errorf("tar content digests differ: %x, %x", a, b)
+	}
+
+	c, err := h.GenerateTarContent(newTar(t, []file{
+		{name: "a.txt", content: "aaa", modTime: older},
+		{name: "dir/b.txt", content: "bbc", modTime: older},
+	}))
+	if err != nil {
+		t.Fatalf("Hash.GenerateTarContent() error = %v", err)
+	}
+	if bytes.Equal(a, c) {
+		t.Errorf("tar content digests of different contents are equal: %x", a)
+	}
+
+	if _, err := h.GenerateTarContent(strings.NewReader("not a tar archive")); err == nil {
+		t.Error("Hash.GenerateTarContent() error = nil, want an error")
+	}
+}
+
+func TestWithUTF16LE(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithSha256(), WithUTF16LE())
+
+	tests := []struct {
+		input string
+		utf16 []byte
+	}{
+		{input: "test", utf16: []byte{'t', 0, 'e', 0, 's', 0, 't', 0}},
+		// U+1F600 is encoded as the surrogate pair D83D DE00.
+		{input: "a😀", utf16: []byte{'a', 0, 0x3d, 0xd8, 0x00, 0xde}},
+	}
+
+	for _, tt := range tests {
+		got, err := h.Generate(tt.input)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		want := sha256.Sum256(tt.utf16)
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.Generate(%q) = %x, want %x", tt.input, got, want)
+		}
+		if err := h.Compare(want[:], tt.input); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+	}
+
+	got, err := h.Generate(strings.NewReader("test"))
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if want := sha256.Sum256([]byte("test")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Hash.Generate() of io.Reader = %x, want %x", got, want)
+	}
+}
+
+func TestStreamsEqual(t *testing.T) {
+	t.Parallel()
+
+	large := strings.Repeat("0123456789", 10000)
+	errUnread := errors.New("must not be read")
+
+	tests := []struct {
+		name     string
+		a        io.Reader
+		b        io.Reader
+		expected bool
+	}{
+		{
+			name:     "Equal streams",
+			a:        strings.NewReader(large),
+			b:        iotest.HalfReader(strings.NewReader(large)),
+			expected: true,
+		},
+		{
+			name:     "Empty streams",
+			a:        strings.NewReader(""),
+			b:        strings.NewReader(""),
+			expected: true,
+		},
+		{
+			name: "Difference near the start",
+			a: io.MultiReader(
+				strings.NewReader("x"+large[1:defaultBufferSize]), iotest.ErrReader(errUnread)),
+			b: io.MultiReader(
+				strings.NewReader(large[:defaultBufferSize]), iotest.ErrReader(errUnread)),
+			expected: false,
+		},
+		{
+			name:     "Different lengths",
+			a:        strings.NewReader(large),
+			b:        strings.NewReader(large + "0"),
+			expected: false,
+		},
+		{
+			name:     "Different lengths at a chunk boundary",
+			a:        strings.NewReader(large[:defaultBufferSize]),
+			b:        strings.NewReader(large[:defaultBufferSize+1]),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := StreamsEqual(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("StreamsEqual() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("StreamsEqual() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := StreamsEqual(iotest.ErrReader(errUnread), strings.NewReader("")); !errors.Is(err, errUnread) {
+		t.Errorf("StreamsEqual() error = %v, want %v", err, errUnread)
+	}
+}
+
+func TestWithCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Repeat("0123456789", 10)
+	want := sha256.Sum256([]byte(data))
+
+	t.Run("Resume after interruption", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		h := NewHash(WithSha256(), WithCheckpoint(path, 16))
+
+		errInterrupted := errors.New("interrupted")
+		interrupted := io.MultiReader(
+			iotest.OneByteReader(strings.NewReader(data[:50])), iotest.ErrReader(errInterrupted))
+		if _, err := h.Generate(interrupted); !errors.Is(err, errInterrupted) {
+			t.Fatalf("Hash.Generate() error = %v, want %v", err, errInterrupted)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("checkpoint was not saved: %v", err)
+		}
+
+		// Resume from a non-seekable reader, which must skip the hashed bytes by reading them.
+		got, err := h.ResumeFrom(path, iotest.HalfReader(strings.NewReader(data)))
+		if err != nil {
+			t.Fatalf("Hash.ResumeFrom() error = %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.ResumeFrom() = %x, want %x", got, want)
+		}
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("checkpoint was not removed after completion: %v", err)
+		}
+	})
+
+	t.Run("Resume from a seekable reader without checkpointing", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		interrupted := io.MultiReader(
+			iotest.OneByteReader(strings.NewReader(data[:30])), iotest.ErrReader(io.ErrUnexpectedEOF))
+		if _, err := NewHash(WithSha256(), WithCheckpoint(path, 10)).Generate(interrupted); err == nil {
+			t.Fatal("Hash.Generate() error = nil, want an error")
+		}
+
+		got, err := NewHash(WithSha256()).ResumeFrom(path, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Hash.ResumeFrom() error = %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.ResumeFrom() = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("Algorithm without marshalable state", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		h := NewHash(WithUserDifinedAlgorithm(&userHash{}), WithCheckpoint(path, 16))
+		if _, err := h.Generate(strings.NewReader(data)); !errors.Is(err, ErrStateNotSupported) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrStateNotSupported)
+		}
+		if _, err := h.ResumeFrom(path, strings.NewReader(data)); !errors.Is(err, ErrStateNotSupported) {
+			t.Errorf("Hash.ResumeFrom() error = %v, want %v", err, ErrStateNotSupported)
+		}
+	})
+
+	t.Run("Checkpoint of another algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "checkpoint")
+		interrupted := io.MultiReader(
+			iotest.OneByteReader(strings.NewReader(data[:30])), iotest.ErrReader(io.ErrUnexpectedEOF))
+		if _, err := NewHash(WithMd5(), WithCheckpoint(path, 10)).Generate(interrupted); err == nil {
+			t.Fatal("Hash.Generate() error = nil, want an error")
+		}
+		if _, err := NewHash(WithSha256()).ResumeFrom(path, strings.NewReader(data)); !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Errorf("Hash.ResumeFrom() error = %v, want %v", err, ErrInvalidCheckpoint)
+		}
+	})
+}
+
+func TestWithPhashMaxDimension(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Large image", func(t *testing.T) {
+		t.Parallel()
+
+		img := image.NewRGBA(image.Rect(0, 0, 4000, 3000))
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatalf("png.Encode() error = %v", err)
+		}
+
+		h := NewHash(WithPhash(), WithPhashMaxDimension(256))
+		if _, err := h.Generate(bytes.NewReader(buf.Bytes())); !errors.Is(err, ErrImageTooLarge) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrImageTooLarge)
+		}
+		if _, err := h.Generate(img); !errors.Is(err, ErrImageTooLarge) {
+			t.Errorf("Hash.Generate(image.Image) error = %v, want %v", err, ErrImageTooLarge)
+		}
+	})
+
+	t.Run("Image smaller than the limit", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open(filepath.Join("testdata", "test.jpg"))
+		if err != nil {
+			t.Fatalf("os.Open() error = %v", err)
+		}
+		defer f.Close() //nolint:errcheck
+
+		got, err := NewHash(WithPhash(), WithPhashMaxDimension(1<<16)).Generate(f)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if want := "6917092734e3ec3a"; hex.EncodeToString(got) != want {
+			t.Errorf("Hash.Generate() = %x, want %s", got, want)
+		}
+	})
+
+	t.Run("Not an image", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewHash(WithPhash(), WithPhashMaxDimension(256)).Generate(strings.NewReader("not an image"))
+		if !errors.Is(err, ErrInvalidImage) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrInvalidImage)
+		}
+	})
+
+	t.Run("Not perceptual hash", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithPhashMaxDimension(256))
+		if h.Algorithm() != "sha256" {
+			t.Errorf("Hash.Algorithm() = %q, want %q", h.Algorithm(), "sha256")
+		}
+		if _, err := h.Generate("test"); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrInvalidOption)
+		}
+	})
+}
+
+func TestHash_CompareAny(t *testing.T) {
+	t.Parallel()
+
+	decode := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatalf("hex.DecodeString() error = %v", err)
+		}
+		return b
+	}
+	expected := [][]byte{
+		decode("7b4bc55c9a1295ecbd2b77a636565f27"),
+		decode("098f6bcd4621d373cade4e832627b4f6"),
+		decode("d41d8cd98f00b204e9800998ecf8427e"),
+	}
+
+	h := NewHash()
+	if err := h.CompareAny(expected, "test"); err != nil {
+		t.Errorf("Hash.CompareAny() error = %v", err)
+	}
+	if err := h.CompareAny(expected, strings.NewReader("test")); err != nil {
+		t.Errorf("Hash.CompareAny() error = %v", err)
+	}
+	if err := h.CompareAny(expected, "none"); !errors.Is(err, ErrHashMismatch) || err.Error() != "md5: hash mismatch" {
+		t.Errorf("Hash.CompareAny() error = %v, want %v prefixed with the algorithm", err, ErrHashMismatch)
+	}
+	if err := h.CompareAny(nil, "test"); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.CompareAny() error = %v, want %v", err, ErrHashMismatch)
+	}
+	if err := h.CompareAny(expected, 1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.CompareAny() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+	// The lengths are checked before the input is read.
+	short := append(expected[:2:2], expected[2][:4])
+	if err := h.CompareAny(short, iotest.ErrReader(errFlakyRead)); !errors.Is(err, ErrInvalidHashLength) {
+		t.Errorf("Hash.CompareAny() error = %v, want %v", err, ErrInvalidHashLength)
+	}
+
+	t.Run("bcrypt", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithBcrypt(bcrypt.MinCost))
+		oldHash, err := h.Generate("old password")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		newHash, err := h.Generate("new password")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+
+		expected := [][]byte{oldHash, newHash}
+		for _, input := range []func() any{
+			func() any { return "new password" },
+			func() any { return []byte("old password") },
+		} {
+			if err := h.CompareAny(expected, input()); err != nil {
+				t.Errorf("Hash.CompareAny(%T) error = %v", input(), err)
+			}
+		}
+		if err := h.CompareAny(expected, "wrong password"); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.CompareAny() error = %v, want %v", err, ErrHashMismatch)
+		}
+	})
+
+	t.Run("wrapped algorithm and io.Reader input", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithSalt([]byte("salt"), SaltPrefix))
+		want, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if err := h.CompareAny([][]byte{make([]byte, 32), want}, strings.NewReader("test")); err != nil {
+			t.Errorf("Hash.CompareAny() error = %v", err)
+		}
+	})
+}
+
+func TestHash_GenerateGroupedHex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		groupSize   int
+		sep         string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:      "Groups of 4 separated by spaces",
+			groupSize: 4,
+			sep:       " ",
+			expected:  "a94a 8fe5 ccb1 9ba6 1c4c 0873 d391 e987 982f bbd3",
+		},
+		{
+			name:      "Groups of 16 separated by colons",
+			groupSize: 16,
+			sep:       ":",
+			expected:  "a94a8fe5ccb19ba6:1c4c0873d391e987:982fbbd3",
+		},
+		{
+			name:      "Group larger than the digest",
+			groupSize: 100,
+			sep:       " ",
+			expected:  "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+		},
+		{
+			name:        "Invalid group size",
+			groupSize:   0,
+			sep:         " ",
+			expectedErr: ErrInvalidGroupSize,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHash(WithSha1()).GenerateGroupedHex("test", tt.groupSize, tt.sep)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Hash.GenerateGroupedHex() error = %v, want %v", err, tt.expectedErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Hash.GenerateGroupedHex() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCRC8CheckValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opt      Option
+		expected string
+	}{
+		{name: "CRC-8/SMBUS", opt: WithCRC8SMBus(), expected: "f4"},
+		{name: "CRC-8/MAXIM", opt: WithCRC8Maxim(), expected: "a1"},
+		{name: "CRC-8/SMBUS by polynomial", opt: WithCRC8(CRC8SMBus), expected: "f4"},
+		{name: "CRC-8 polynomial 0x1d", opt: WithCRC8(0x1d), expected: "37"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewHash(tt.opt).Generate("123456789")
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.expected {
+				t.Errorf("Generate() = %x, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHash_GenerateWithObserver(t *testing.T) {
+	t.Parallel()
+
+	type observation struct {
+		algorithm string
+		bytes     int64
+		d         time.Duration
+	}
+
+	tests := []struct {
+		name  string
+		input func() any
+		bytes int64
+	}{
+		{name: "string", input: func() any { return "test" }, bytes: 4},
+		{name: "io.Reader", input: func() any { return strings.NewReader(strings.Repeat("a", 100000)) }, bytes: 100000},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []observation
+			h := NewHash(WithSha256(), WithObserver(func(algorithm string, bytes int64, d time.Duration) {
+				got = append(got, observation{algorithm: algorithm, bytes: bytes, d: d})
+			}))
+
+			digest, err := h.Generate(tt.input())
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			want, err := NewHash(WithSha256()).Generate(tt.input())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(digest, want) {
+				t.Errorf("Generate() = %x, want %x", digest, want)
+			}
+
+			if len(got) != 1 {
+				t.Fatalf("observer called %d times, want 1", len(got))
+			}
+			if got[0].algorithm != "sha256" {
+				t.Errorf("algorithm = %q, want %q", got[0].algorithm, "sha256")
+			}
+			if got[0].bytes != tt.bytes {
+				t.Errorf("bytes = %d, want %d", got[0].bytes, tt.bytes)
+			}
+			if got[0].d <= 0 {
+				t.Errorf("duration = %v, want positive", got[0].d)
+			}
+		})
+	}
+}
+
+func TestHash_ObserverOperations(t *testing.T) {
+	t.Parallel()
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, name := range []string{"a", "b"} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: 4}); err != nil {
+			t.Fatalf("tar.Writer.WriteHeader() error = %v", err)
+		}
+		if _, err := tw.Write([]byte("test")); err != nil {
+			t.Fatalf("tar.Writer.Write() error = %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Writer.Close() error = %v", err)
+	}
+
+	data := strings.Repeat("a", 10)
+	tests := []struct {
+		name  string
+		run   func(h *Hash) error
+		bytes []int64
+	}{
+		{
+			name: "TreeHash",
+			run: func(h *Hash) error {
+				_, _, err := h.TreeHash(strings.NewReader(data), 3)
+				return err
+			},
+			bytes: []int64{10},
+		},
+		{
+			name: "TreeHashAt",
+			run: func(h *Hash) error {
+				_, _, err := h.TreeHashAt(strings.NewReader(data), int64(len(data)), 3)
+				return err
+			},
+			bytes: []int64{10},
+		},
+		{
+			name: "GenerateTarContent",
+			run: func(h *Hash) error {
+				_, err := h.GenerateTarContent(bytes.NewReader(archive.Bytes()))
+				return err
+			},
+			bytes: []int64{int64(archive.Len())},
+		},
+		{
+			name: "GenerateBatch",
+			run: func(h *Hash) error {
+				_, errs := h.GenerateBatch([]any{"ab", strings.NewReader("cde"), []byte("f")})
+				return errors.Join(errs...)
+			},
+			bytes: []int64{6},
+		},
+		{
+			name: "Chunker",
+			run: func(h *Hash) error {
+				c, err := NewChunker(h, strings.NewReader(data), 4, 4, 4)
+				if err != nil {
+					return err
+				}
+				for {
+					if _, _, err := c.Next(); err != nil {
+						if errors.Is(err, io.EOF) {
+							return nil
+						}
+						return err
+					}
+				}
+			},
+			bytes: []int64{4, 4, 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				mu  sync.Mutex
+				got []int64
+			)
+			h := NewHash(WithSha256(), WithConcurrency(2), WithObserver(func(_ string, bytes int64, _ time.Duration) {
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, bytes)
+			}))
+			if err := tt.run(h); err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.bytes) {
+				t.Errorf("observed bytes = %v, want %v", got, tt.bytes)
+			}
+		})
+	}
+}
+
+func TestHash_GenerateWithBlake2bKeyed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("different keys yield different digests", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := NewHash(WithBlake2bKeyed([]byte("key-a"))).Generate("test")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		b, err := NewHash(WithBlake2bKeyed([]byte("key-b"))).Generate(strings.NewReader("test"))
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if len(a) != 64 {
+			t.Errorf("len(Generate()) = %d, want 64", len(a))
+		}
+		if bytes.Equal(a, b) {
+			t.Errorf("digests with different keys are equal: %x", a)
+		}
+		if err := NewHash(WithBlake2bKeyed([]byte("key-a"))).Compare(a, strings.NewReader("test")); err != nil {
+			t.Errorf("Compare() error = %v", err)
+		}
+		if err := NewHash(WithBlake2bKeyed([]byte("key-b"))).Compare(a, "test"); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+	})
+
+	t.Run("over-length key", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithBlake2bKeyed(make([]byte, 65)))
+		if _, err := h.Generate("test"); !errors.Is(err, ErrInvalidKeyLength) {
+			t.Errorf("Generate() error = %v, want %v", err, ErrInvalidKeyLength)
+		}
+		if err := h.Compare(nil, strings.NewReader("test")); !errors.Is(err, ErrInvalidKeyLength) {
+			t.Errorf("Compare() error = %v, want %v", err, ErrInvalidKeyLength)
+		}
+	})
+}
+
+func TestHash_GenerateWithHMAC(t *testing.T) {
+	t.Parallel()
+
+	// Test cases 1 and 2 of RFC 4231.
+	tests := []struct {
+		name     string
+		opt      Option
+		input    string
+		expected string
+	}{
+		{
+			name:     "HMAC-SHA256 test case 1",
+			opt:      WithHMACSha256(bytes.Repeat([]byte{0x0b}, 20)),
+			input:    "Hi There",
+			expected: "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+		},
+		{
+			name:     "HMAC-SHA256 test case 2",
+			opt:      WithHMACSha256([]byte("Jefe")),
+			input:    "what do ya want for nothing?",
+			expected: "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+		{
+			name:     "HMAC-SHA512 test case 1",
+			opt:      WithHMACSha512(bytes.Repeat([]byte{0x0b}, 20)),
+			input:    "Hi There",
+			expected: "87aa7cdea5ef619d4ff0b4241a1d6cb02379f4e2ce4ec2787ad0b30545e17cdedaa833b7d6b8a702038b274eaea3f4e4be9d914eeb61f1702e696c203a126854",
+		},
+		{
+			name:     "HMAC with sha256.New test case 2",
+			opt:      WithHMAC([]byte("Jefe"), sha256.New),
+			input:    "what do ya want for nothing?",
+			expected: "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opt)
+			got, err := h.Generate(tt.input)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.expected {
+				t.Errorf("Generate() = %x, want %s", got, tt.expected)
+			}
+
+			got, err = h.Generate(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.expected {
+				t.Errorf("Generate() = %x, want %s", got, tt.expected)
+			}
+
+			if err := h.Compare(got, tt.input); err != nil {
+				t.Errorf("Compare() error = %v", err)
+			}
+			// A truncated or extended MAC must not match, whatever the length.
+			if err := h.Compare(got[:len(got)-1], tt.input); !errors.Is(err, ErrInvalidHashLength) {
+				t.Errorf("Compare() with truncated MAC error = %v, want %v", err, ErrInvalidHashLength)
+			}
+			if err := h.Compare(append(got, 0), strings.NewReader(tt.input)); !errors.Is(err, ErrInvalidHashLength) {
+				t.Errorf("Compare() with extended MAC error = %v, want %v", err, ErrInvalidHashLength)
+			}
+			if err := h.Compare(nil, tt.input); !errors.Is(err, ErrInvalidHashLength) {
+				t.Errorf("Compare() with empty MAC error = %v, want %v", err, ErrInvalidHashLength)
+			}
+		})
+	}
+}
+
+func TestHash_CompareMismatchLength(t *testing.T) {
+	t.Parallel()
+
+	opts := map[string]Option{
+		"md5":         WithMd5(),
+		"sha256":      WithSha256(),
+		"fnv32":       WithFnv32(),
+		"fnv64":       WithFnv64(),
+		"crc8-smbus":  WithCRC8SMBus(),
+		"blake2b-256": WithBlake2b_256(),
+		"blake3":      WithBlake3(),
+	}
+	for name, opt := range opts {
+		name, opt := name, opt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(opt)
+			digest, err := h.Generate("test")
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			// A hash of the same length that differs only in the last byte is a mismatch.
+			flipped := bytes.Clone(digest)
+			flipped[len(flipped)-1] ^= 0xff
+			if err := h.Compare(flipped, "test"); !errors.Is(err, ErrHashMismatch) {
+				t.Errorf("Compare(%x, string) error = %v, want %v", flipped, err, ErrHashMismatch)
+			}
+			if err := h.Compare(flipped, strings.NewReader("test")); !errors.Is(err, ErrHashMismatch) {
+				t.Errorf("Compare(%x, io.Reader) error = %v, want %v", flipped, err, ErrHashMismatch)
+			}
+
+			// A truncated, extended, or empty hash has an invalid length.
+			for _, hash := range [][]byte{digest[:len(digest)-1], append(bytes.Clone(digest), 0), nil} {
+				if err := h.Compare(hash, "test"); !errors.Is(err, ErrInvalidHashLength) {
+					t.Errorf("Compare(%x, string) error = %v, want %v", hash, err, ErrInvalidHashLength)
+				}
+				if err := h.Compare(hash, strings.NewReader("test")); !errors.Is(err, ErrInvalidHashLength) {
+					t.Errorf("Compare(%x, io.Reader) error = %v, want %v", hash, err, ErrInvalidHashLength)
+				}
+			}
+		})
+	}
+}
+
+func TestHash_Writer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "md5", opts: []Option{WithMd5()}},
+		{name: "sha256", opts: []Option{WithSha256()}},
+		{name: "fnv32", opts: []Option{WithFnv32()}},
+		{name: "blake3", opts: []Option{WithBlake3()}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			w, err := h.Writer()
+			if err != nil {
+				t.Fatalf("Hash.Writer() error = %v", err)
+			}
+			for _, s := range []string{"te", "st"} {
+				if _, err := io.WriteString(w, s); err != nil {
+					t.Fatalf("DigestWriter.Write() error = %v", err)
+				}
+			}
+
+			want, err := h.Generate("test")
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if got := w.Sum(); !bytes.Equal(got, want) {
+				t.Errorf("DigestWriter.Sum() = %x, want %x", got, want)
+			}
+
+			w.Reset()
+			if _, err := io.Copy(w, strings.NewReader("test")); err != nil {
+				t.Fatalf("io.Copy() error = %v", err)
+			}
+			if got := w.Sum(); !bytes.Equal(got, want) {
+				t.Errorf("DigestWriter.Sum() after Reset = %x, want %x", got, want)
+			}
+		})
+	}
+
+	for _, opt := range []Option{WithPhash(), WithBcrypt(bcrypt.MinCost)} {
+		if _, err := NewHash(opt).Writer(); !errors.Is(err, ErrStreamingNotSupported) {
+			t.Errorf("Hash.Writer() error = %v, want %v", err, ErrStreamingNotSupported)
+		}
+	}
+}
+
+// slowReader is an io.Reader that returns one byte per read after a delay, and never ends.
+type slowReader struct {
+	delay time.Duration
+}
+
+func (s *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	if len(p) == 0 {
+		return 0, nil
+	}
+	p[0] = 'a'
+	return 1, nil
+}
+
+func TestHash_GenerateContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generates the same hash as Generate", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		want, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		for _, input := range []any{"test", strings.NewReader("test")} {
+			got, err := h.GenerateContext(context.Background(), input)
+			if err != nil {
+				t.Fatalf("Hash.GenerateContext() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Hash.GenerateContext() = %x, want %x", got, want)
+			}
+		}
+	})
+
+	t.Run("cancelled while reading", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := NewHash(WithSha256(), WithRetry(3)).GenerateContext(ctx, &slowReader{delay: time.Millisecond})
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Hash.GenerateContext() error = %v, want %v", err, context.Canceled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Hash.GenerateContext() did not return after the context was cancelled")
+		}
+	})
+
+	t.Run("cancelled before hashing", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for _, input := range []any{"test", strings.NewReader("test")} {
+			_, err := NewHash().GenerateContext(ctx, input)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Hash.GenerateContext() error = %v, want %v", err, context.Canceled)
+			}
+			if err != nil && err.Error() != "md5: context canceled" {
+				t.Errorf("Hash.GenerateContext() error = %q, want %q", err, "md5: context canceled")
+			}
+		}
+	})
+}
+
+func TestMultiHash_GenerateAll(t *testing.T) {
+	t.Parallel()
+
+	opts := map[string]Option{
+		"md5":      WithMd5(),
+		"sha1":     WithSha1(),
+		"sha256":   WithSha256(),
+		"fnv64a":   WithFnv64a(),
+		"blake3":   WithBlake3(),
+		"sha3-256": WithSha3_256(),
+		// The keyed modes must not be mistaken for their unkeyed algorithms.
+		"blake3-keyed":      WithBlake3Keyed([32]byte{1}),
+		"blake2b-512":       WithBlake2b_512(),
+		"blake2b-512-keyed": WithBlake2bKeyed([]byte("key")),
+	}
+	all := make([]Option, 0, len(opts))
+	for _, opt := range opts {
+		all = append(all, opt)
+	}
+
+	t.Run("streamable algorithms", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []func() any{
+			func() any { return "test" },
+			func() any { return iotest.OneByteReader(strings.NewReader("test")) },
+		} {
+			got, err := NewMultiHash(all...).GenerateAll(input())
+			if err != nil {
+				t.Fatalf("MultiHash.GenerateAll() error = %v", err)
+			}
+			if len(got) != len(opts) {
+				t.Errorf("MultiHash.GenerateAll() returned %d hashes, want %d", len(got), len(opts))
+			}
+			for name, opt := range opts {
+				want, err := NewHash(opt).Generate(input())
+				if err != nil {
+					t.Fatalf("Hash.Generate() error = %v", err)
+				}
+				if !bytes.Equal(got[name], want) {
+					t.Errorf("MultiHash.GenerateAll()[%s] = %x, want %x", name, got[name], want)
+				}
+			}
+		}
+	})
+
+	t.Run("with perceptual hash", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := os.ReadFile(filepath.Join("testdata", "test.jpg"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The unread content of a *bytes.Buffer is hashed without copying it.
+		for _, input := range []io.Reader{bytes.NewReader(b), bytes.NewBuffer(bytes.Clone(b))} {
+			got, err := NewMultiHash(WithPhash(), WithSha256()).GenerateAll(input)
+			if err != nil {
+				t.Fatalf("MultiHash.GenerateAll() error = %v", err)
+			}
+			for name, opt := range map[string]Option{"phash": WithPhash(), "sha256": WithSha256()} {
+				want, err := NewHash(opt).Generate(b)
+				if err != nil {
+					t.Fatalf("Hash.Generate() error = %v", err)
+				}
+				if !bytes.Equal(got[name], want) {
+					t.Errorf("MultiHash.GenerateAll(%T)[%s] = %x, want %x", input, name, got[name], want)
+				}
+			}
+		}
+	})
+
+	t.Run("failing algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := NewMultiHash(WithPhash(), WithMd5()).GenerateAll("test")
+		if !errors.Is(err, ErrPhashNotSupportedString) {
+			t.Errorf("MultiHash.GenerateAll() error = %v, want %v", err, ErrPhashNotSupportedString)
+		}
+		if _, ok := got["phash"]; ok {
+			t.Errorf("MultiHash.GenerateAll() returned a hash for a failing algorithm")
+		}
+		if hex.EncodeToString(got["md5"]) != "098f6bcd4621d373cade4e832627b4f6" {
+			t.Errorf("MultiHash.GenerateAll()[md5] = %x", got["md5"])
+		}
+	})
+
+	t.Run("unsupported input", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewMultiHash(WithMd5()).GenerateAll(1); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("MultiHash.GenerateAll() error = %v, want %v", err, ErrUnsupportedInputType)
+		}
+	})
+}
+
+// stringerReader implements both fmt.Stringer and io.Reader.
+type stringerReader struct {
+	*strings.Reader
+}
+
+func (s stringerReader) String() string {
+	return "not the content"
+}
+
+// stringer implements fmt.Stringer only.
+type stringer struct{}
+
+func (stringer) String() string {
+	return "test"
+}
+
+func TestHash_GenerateInputTypes(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "test")
+	if err := os.WriteFile(file, []byte("test"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input func(t *testing.T) any
+	}{
+		{name: "string", input: func(*testing.T) any { return "test" }},
+		{name: "[]byte", input: func(*testing.T) any { return []byte("test") }},
+		{name: "*bytes.Buffer", input: func(*testing.T) any { return bytes.NewBufferString("test") }},
+		{name: "*bytes.Reader", input: func(*testing.T) any { return bytes.NewReader([]byte("test")) }},
+		{name: "*strings.Reader", input: func(*testing.T) any { return strings.NewReader("test") }},
+		{name: "fmt.Stringer and io.Reader", input: func(*testing.T) any { return stringerReader{strings.NewReader("test")} }},
+		{name: "*os.File", input: func(t *testing.T) any {
+			t.Helper()
+			f, err := os.Open(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { f.Close() })
+			return f
+		}},
+		{name: "ReaderFunc", input: func(*testing.T) any {
+			return ReaderFunc(func() (io.Reader, error) { return strings.NewReader("test"), nil })
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(WithSha256())
+			got, err := h.Generate(tt.input(t))
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if want := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"; hex.EncodeToString(got) != want {
+				t.Errorf("Hash.Generate() = %x, want %s", got, want)
+			}
+			if err := h.Compare(got, tt.input(t)); err != nil {
+				t.Errorf("Hash.Compare() error = %v", err)
+			}
+		})
+	}
+
+	for _, input := range []any{stringer{}, 1, nil, []string{"test"}} {
+		if _, err := NewHash().Generate(input); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.Generate(%T) error = %v, want %v", input, err, ErrUnsupportedInputType)
+		}
+		if err := NewHash().Compare(make([]byte, 16), input); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.Compare(%T) error = %v, want %v", input, err, ErrUnsupportedInputType)
+		}
+	}
+}
+
+func TestHash_GeneratePhashFromBytes(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile(filepath.Join("testdata", "test.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewHash(WithPhash()).Generate(b)
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if want := "6917092734e3ec3a"; hex.EncodeToString(got) != want {
+		t.Errorf("Hash.Generate() = %x, want %s", got, want)
+	}
+}
+
+func TestHash_GenerateReused(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "md5", opts: []Option{WithMd5()}},
+		{name: "sha256", opts: []Option{WithSha256()}},
+		{name: "hmac-sha256", opts: []Option{WithHMACSha256([]byte("key"))}},
+		{name: "not backed by hash.Hash", opts: []Option{WithSha256(), WithTrimSpace()}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					input := strings.Repeat("a", i*1000)
+					want, err := h.Generate(input)
+					if err != nil {
+						t.Errorf("Hash.Generate() error = %v", err)
+						return
+					}
+					for _, in := range []any{input, strings.NewReader(input), []byte(input)} {
+						got, err := h.GenerateReused(in)
+						if err != nil {
+							t.Errorf("Hash.GenerateReused() error = %v", err)
+							return
+						}
+						if !bytes.Equal(got, want) {
+							t.Errorf("Hash.GenerateReused(%T) = %x, want %x", in, got, want)
+						}
+					}
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+
+	if _, err := NewHash().GenerateReused(1); !errors.Is(err, ErrUnsupportedInputType) {
+		t.Errorf("Hash.GenerateReused() error = %v, want %v", err, ErrUnsupportedInputType)
+	}
+
+	t.Run("pool is created on first use", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		if h.hashPool.Load() != nil {
+			t.Fatal("NewHash() created the pool before GenerateReused was called")
+		}
+		if _, err := h.GenerateReused("test"); err != nil {
+			t.Fatalf("Hash.GenerateReused() error = %v", err)
+		}
+		if h.hashPool.Load() == nil {
+			t.Error("Hash.GenerateReused() did not create the pool")
+		}
+	})
+
+	t.Run("large one-shot buffers are not pooled", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithFarmHash64())
+		if _, err := h.GenerateReused(strings.Repeat("a", 4*maxPooledBufferSize)); err != nil {
+			t.Fatalf("Hash.GenerateReused() error = %v", err)
+		}
+		d := h.hashPool.Load().Get().(*oneShotDigest) //nolint:forcetypeassert
+		if d.buf.Cap() > maxPooledBufferSize {
+			t.Errorf("pooled buffer capacity = %d, want at most %d", d.buf.Cap(), maxPooledBufferSize)
+		}
+	})
+}
+
+func BenchmarkHash_GenerateReused(b *testing.B) {
+	h := NewHash(WithSha256())
+	benchmarks := []struct {
+		name string
+		gen  func(input any) ([]byte, error)
+	}{
+		{name: "Generate", gen: h.Generate},
+		{name: "GenerateReused", gen: h.GenerateReused},
+	}
+
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := bm.gen("small input for a busy server"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestHash_GenerateFile(t *testing.T) {
+	t.Parallel()
+
+	const want = "7b4bc55c9a1295ecbd2b77a636565f27"
+	path := filepath.Join("testdata", "test.txt")
+
+	h := NewHash()
+	got, err := h.GenerateFile(path)
+	if err != nil {
+		t.Fatalf("Hash.GenerateFile() error = %v", err)
+	}
+	if hex.EncodeToString(got) != want {
+		t.Errorf("Hash.GenerateFile() = %x, want %s", got, want)
+	}
+
+	if err := h.CompareFile(got, path); err != nil {
+		t.Errorf("Hash.CompareFile() error = %v", err)
+	}
+	if err := h.CompareFile(got, filepath.Join("testdata", "mismatch.txt")); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.CompareFile() error = %v, want %v", err, ErrHashMismatch)
+	}
+
+	missing := filepath.Join("testdata", "missing.txt")
+	if _, err := h.GenerateFile(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Hash.GenerateFile() error = %v, want %v", err, os.ErrNotExist)
+	}
+	if err := h.CompareFile(got, missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Hash.CompareFile() error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+// namedUserHash is a user-defined Hasher that implements Named.
+type namedUserHash struct {
+	userHash
+}
+
+func (n *namedUserHash) Name() string {
+	return "my-hash"
+}
+
+func TestHash_Algorithm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{name: "default", opts: nil, expected: string(DefaultAlgorithm)},
+		{name: "sha256", opts: []Option{WithSha256()}, expected: "sha256"},
+		{name: "fnv64a", opts: []Option{WithFnv64a()}, expected: string(AlgoFNV64a)},
+		{name: "phash", opts: []Option{WithPhash()}, expected: "phash"},
+		{name: "wrapped by an option", opts: []Option{WithSha256(), WithTrimSpace()}, expected: "sha256"},
+		{name: "concatenated", opts: []Option{WithConcatenate(WithMd5(), WithSha1())}, expected: "md5+sha1"},
+		{name: "user-defined", opts: []Option{WithUserDifinedAlgorithm(&userHash{})}, expected: "user-defined"},
+		{name: "user-defined with Named", opts: []Option{WithUserDifinedAlgorithm(&namedUserHash{})}, expected: "my-hash"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewHash(tt.opts...).Algorithm(); got != tt.expected {
+				t.Errorf("Hash.Algorithm() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHash_CompareInvalidHashLength(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		input func() any
+	}{
+		{name: "sha256", opts: []Option{WithSha256()}, input: func() any { return "test" }},
+		{name: "sha256 with a prefix", opts: []Option{WithSha256(), WithCompactSizePrefix()}, input: func() any { return "test" }},
+		{name: "phash", opts: []Option{WithPhash()}, input: func() any { return strings.NewReader("not read") }},
+		{name: "concatenated", opts: []Option{WithConcatenate(WithMd5(), WithSha1())}, input: func() any { return "test" }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			if h.size.Load() != 0 {
+				t.Fatal("NewHash() computed the hash length before it was needed")
+			}
+			for i := 0; i < 2; i++ {
+				err := h.Compare([]byte{1, 2, 3}, tt.input())
+				if !errors.Is(err, ErrInvalidHashLength) {
+					t.Errorf("Hash.Compare() error = %v, want %v", err, ErrInvalidHashLength)
+				}
+			}
+		})
+	}
+
+	// The length of user-defined hashes is unknown, so it is not checked.
+	if err := NewHash(WithUserDifinedAlgorithm(&userHash{})).Compare([]byte("test"), "test"); err != nil {
+		t.Errorf("Hash.Compare() error = %v", err)
+	}
+}
+
+func TestWithArgon2id(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opt      Option
+		password string
+		expected string
+	}{
+		{
+			// Test vector of the Argon2 reference implementation (phc-winner-argon2).
+			name:     "reference vector",
+			opt:      WithArgon2id([]byte("somesalt"), 2, 1<<16, 1, 32),
+			password: "password",
+			expected: "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7",
+		},
+		{
+			name:     "small parameters",
+			opt:      WithArgon2id([]byte("somesaltsomesalt"), 2, 256, 2, 16),
+			password: "password",
+			expected: "bc6ec0b7fd04348a3b0cbf9c338b2057",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opt)
+			got, err := h.Generate(tt.password)
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != tt.expected {
+				t.Errorf("Hash.Generate() = %x, want %s", got, tt.expected)
+			}
+			if err := h.Compare(got, tt.password); err != nil {
+				t.Errorf("Hash.Compare() error = %v", err)
+			}
+			if err := h.Compare(got, "wrong password"); !errors.Is(err, ErrHashMismatch) {
+				t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+			}
+		})
+	}
+
+	h := NewHash(WithArgon2id([]byte("somesaltsomesalt"), 1, 64, 1, 0))
+	if got, err := h.Generate("password"); err != nil || len(got) != 32 {
+		t.Errorf("Hash.Generate() = %x, %v, want a 32-byte hash by default", got, err)
+	}
+	if _, err := h.Generate(strings.NewReader("password")); !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("Hash.Generate() error = %v, want %v", err, ErrStreamingNotSupported)
+	}
+	if err := h.Compare(make([]byte, 32), strings.NewReader("password")); !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("Hash.Compare() error = %v, want %v", err, ErrStreamingNotSupported)
+	}
+}
+
+func TestWithSalt(t *testing.T) {
+	t.Parallel()
+
+	salt := []byte("salt")
+	unsalted, err := NewHash(WithMd5()).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		mode  SaltMode
+		plain string
+	}{
+		{name: "prefix", mode: SaltPrefix, plain: "salttest"},
+		{name: "suffix", mode: SaltSuffix, plain: "testsalt"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want, err := NewHash(WithMd5()).Generate(tt.plain)
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+
+			h := NewHash(WithMd5(), WithSalt(salt, tt.mode))
+			for _, input := range []func() any{
+				func() any { return "test" },
+				func() any { return strings.NewReader("test") },
+			} {
+				got, err := h.Generate(input())
+				if err != nil {
+					t.Fatalf("Hash.Generate() error = %v", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("Hash.Generate() = %x, want %x", got, want)
+				}
+				if bytes.Equal(got, unsalted) {
+					t.Errorf("Hash.Generate() = %x, want it to differ from the unsalted hash", got)
+				}
+				if err := h.Compare(want, input()); err != nil {
+					t.Errorf("Hash.Compare() error = %v", err)
+				}
+			}
+		})
+	}
+
+	prefixed, err := NewHash(WithMd5(), WithSalt(salt, SaltPrefix)).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	suffixed, err := NewHash(WithMd5(), WithSalt(salt, SaltSuffix)).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if bytes.Equal(prefixed, suffixed) {
+		t.Errorf("prefix and suffix salts yield the same hash %x", prefixed)
+	}
+}
+
+func TestWithXXHash_Vector(t *testing.T) {
+	t.Parallel()
+
+	// The digest of "test" must not change with the version of the xxhash module.
+	const want = "4fdcca5ddb678139"
+
+	h := NewHash(WithXXHash())
+	w, err := h.Writer()
+	if err != nil {
+		t.Fatalf("Hash.Writer() error = %v", err)
+	}
+	if _, err := io.WriteString(w, "test"); err != nil {
+		t.Fatalf("DigestWriter.Write() error = %v", err)
+	}
+	if got := hex.EncodeToString(w.Sum()); got != want {
+		t.Errorf("DigestWriter.Sum() = %s, want %s", got, want)
+	}
+
+	for _, input := range []any{"test", strings.NewReader("test")} {
+		got, err := h.Generate(input)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if hex.EncodeToString(got) != want {
+			t.Errorf("Hash.Generate(%T) = %x, want %s", input, got, want)
+		}
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    []byte
+		b    []byte
+		want int
+	}{
+		{name: "equal", a: []byte{0xff, 0x00}, b: []byte{0xff, 0x00}, want: 0},
+		{name: "one bit", a: []byte{0x01, 0x00}, b: []byte{0x00, 0x00}, want: 1},
+		{name: "all bits", a: []byte{0xff, 0xff}, b: []byte{0x00, 0x00}, want: 16},
+		{name: "different lengths", a: []byte{0x0f}, b: []byte{0x0f, 0x00}, want: 8},
+		{name: "empty", a: nil, b: nil, want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := HammingDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("HammingDistance() = %d, want %d", got, tt.want)
+			}
+			if got := HammingDistance(tt.b, tt.a); got != tt.want {
+				t.Errorf("HammingDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHash_CompareWithThreshold(t *testing.T) {
+	t.Parallel()
+
+	// test_similar.jpg is test.jpg slightly cropped and brightened; their perceptual hashes differ in 6 bits.
+	hash, err := hex.DecodeString("6917092734e3ec3a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		file        string
+		maxDistance int
+		expectedErr error
+	}{
+		{name: "same image", file: "test.jpg", maxDistance: 0, expectedErr: nil},
+		{name: "similar image within threshold", file: "test_similar.jpg", maxDistance: 10, expectedErr: nil},
+		{name: "similar image over threshold", file: "test_similar.jpg", maxDistance: 5, expectedErr: ErrHashMismatch},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			err = NewHash(WithPhash()).CompareWithThreshold(hash, f, tt.maxDistance)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Hash.CompareWithThreshold() error = %v, want %v", err, tt.expectedErr)
+			}
+		})
+	}
+
+	t.Run("invalid hash length", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewHash(WithPhash()).CompareWithThreshold(hash[:4], strings.NewReader(""), 64)
+		if !errors.Is(err, ErrInvalidHashLength) {
+			t.Errorf("Hash.CompareWithThreshold() error = %v, want %v", err, ErrInvalidHashLength)
+		}
+	})
+
+	t.Run("mismatch error names the algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		f, err := os.Open(filepath.Join("testdata", "test_similar.jpg"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		err = NewHash(WithPhash()).CompareWithThreshold(hash, f, 0)
+		if !errors.Is(err, ErrHashMismatch) {
+			t.Fatalf("Hash.CompareWithThreshold() error = %v, want %v", err, ErrHashMismatch)
+		}
+		if !strings.HasPrefix(err.Error(), "phash: ") {
+			t.Errorf("Hash.CompareWithThreshold() error = %q, want the algorithm name as prefix", err)
+		}
+	})
+
+	t.Run("not a perceptual hash", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithBcrypt(bcrypt.MinCost))
+		password, err := h.Generate("password")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if err := h.CompareWithThreshold(password, "password", 64); !errors.Is(err, ErrNotPerceptual) {
+			t.Errorf("Hash.CompareWithThreshold() error = %v, want %v", err, ErrNotPerceptual)
+		}
+		if err := NewHash(WithSha256()).CompareWithThreshold(make([]byte, 32), "test", 256); !errors.Is(err, ErrNotPerceptual) {
+			t.Errorf("Hash.CompareWithThreshold() error = %v, want %v", err, ErrNotPerceptual)
+		}
+	})
+}
+
+func TestHash_GenerateImage(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("testdata", "test.jpg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("perceptual hash", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithPhash())
+		got, err := h.Generate(img)
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if hex.EncodeToString(got) != "6917092734e3ec3a" {
+			t.Errorf("Hash.Generate() = %x, want 6917092734e3ec3a", got)
+		}
+		if err := h.Compare(got, img); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if err := h.Compare(make([]byte, 8), img); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+	})
+
+	t.Run("not supported by the algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		if _, err := h.Generate(img); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrUnsupportedInputType)
+		}
+		if err := h.Compare(make([]byte, 32), img); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrUnsupportedInputType)
+		}
+	})
+
+	t.Run("wrapped perceptual hash", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name string
+			opts []Option
+			want string
+		}{
+			{name: "salted", opts: []Option{WithPhash(), WithSalt([]byte("salt"), SaltPrefix)}, want: "6917092734e3ec3a"},
+			{name: "truncated", opts: []Option{WithPhash(), WithTruncate(4)}, want: "69170927"},
+			{name: "concatenated", opts: []Option{WithConcatenate(WithPhash(), WithAverageHash())}, want: "6917092734e3ec3a320137777e7c623e"},
+		}
+		for _, tt := range tests {
+			h := NewHash(tt.opts...)
+			got, err := h.Generate(img)
+			if err != nil {
+				t.Fatalf("%s: Hash.Generate() error = %v", tt.name, err)
+			}
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("%s: Hash.Generate() = %x, want %s", tt.name, got, tt.want)
+			}
+			if err := h.Compare(got, img); err != nil {
+				t.Errorf("%s: Hash.Compare() error = %v", tt.name, err)
+			}
+		}
+
+		h := NewHash(WithConcatenate(WithPhash(), WithSha256()))
+		if _, err := h.Generate(img); !errors.Is(err, ErrUnsupportedInputType) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrUnsupportedInputType)
+		}
+		if err := h.CompareWithThreshold(make([]byte, 40), img, 0); !errors.Is(err, ErrNotPerceptual) {
+			t.Errorf("Hash.CompareWithThreshold() error = %v, want %v", err, ErrNotPerceptual)
+		}
+	})
+}
+
+func TestHash_GenerateWithAverageAndDifferenceHash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opt     Option
+		want    string
+		similar string
+	}{
+		{name: "average hash", opt: WithAverageHash(), want: "320137777e7c623e", similar: "222137777e7c623e"},
+		{name: "difference hash", opt: WithDifferenceHash(), want: "624fcac6e4b1c6e4", similar: "42cfcac6e4b1c6e4"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opt)
+			for file, want := range map[string]string{"test.jpg": tt.want, "test_similar.jpg": tt.similar} {
+				f, err := os.Open(filepath.Join("testdata", file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := h.Generate(f)
+				f.Close()
+				if err != nil {
+					t.Fatalf("Hash.Generate() error = %v", err)
+				}
+				if hex.EncodeToString(got) != want {
+					t.Errorf("Hash.Generate(%s) = %x, want %s", file, got, want)
+				}
+			}
+
+			hash, err := hex.DecodeString(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f, err := os.Open(filepath.Join("testdata", "test_similar.jpg"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if err := h.CompareWithThreshold(hash, f, 4); err != nil {
+				t.Errorf("Hash.CompareWithThreshold() error = %v", err)
+			}
+
+			if _, err := h.Generate("test"); !errors.Is(err, ErrPhashNotSupportedString) {
+				t.Errorf("Hash.Generate() error = %v, want %v", err, ErrPhashNotSupportedString)
+			}
+			if err := h.Compare(hash, "test"); !errors.Is(err, ErrPhashNotSupportedString) {
+				t.Errorf("Hash.Compare() error = %v, want %v", err, ErrPhashNotSupportedString)
+			}
+			if _, err := h.Generate(strings.NewReader("not an image")); !errors.Is(err, ErrInvalidImage) {
+				t.Errorf("Hash.Generate() error = %v, want %v", err, ErrInvalidImage)
+			}
+		})
+	}
+}
+
+func TestHash_Clone(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithSha256(), WithBufferSize(1024))
+	want, err := h.Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	c := h.Clone()
+	if c == h {
+		t.Fatal("Hash.Clone() returned the same *Hash")
+	}
+	if c.Algorithm() != h.Algorithm() {
+		t.Errorf("Hash.Clone().Algorithm() = %s, want %s", c.Algorithm(), h.Algorithm())
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 8; i++ {
+		for _, hash := range []*Hash{h, c} {
+			wg.Add(1)
+			go func(hash *Hash) {
+				defer wg.Done()
+				got, err := hash.Generate(strings.NewReader("test"))
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(got, want) {
+					errs <- fmt.Errorf("Hash.Generate() = %x, want %x", got, want)
+				}
+			}(hash)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// TestHash_Concurrent checks that a shared *Hash can be used by multiple goroutines.
+// Run it with go test -race to detect shared state.
+func TestHash_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	h := NewHash(WithSha256())
+	want, err := h.Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	inputs := []func() any{
+		func() any { return "test" },
+		func() any { return []byte("test") },
+		func() any { return strings.NewReader("test") },
+	}
+
+	const goroutines = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*goroutines*len(inputs))
+	for i := 0; i < goroutines; i++ {
+		for _, input := range inputs {
+			wg.Add(1)
+			go func(input func() any) {
+				defer wg.Done()
+
+				got, err := h.Generate(input())
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !bytes.Equal(got, want) {
+					errs <- fmt.Errorf("Hash.Generate() = %x, want %x", got, want)
+				}
+				if got, err := h.GenerateReused(input()); err != nil || !bytes.Equal(got, want) {
+					errs <- fmt.Errorf("Hash.GenerateReused() = %x, %v, want %x", got, err, want)
+				}
+				if err := h.Compare(want, input()); err != nil {
+					errs <- err
+				}
+			}(input)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestHash_GenerateBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "md5", opts: []Option{WithMd5()}},
+		{name: "sha256", opts: []Option{WithSha256()}},
+		{name: "blake2b-256", opts: []Option{WithBlake2b_256()}},
+		{name: "blake3", opts: []Option{WithBlake3()}},
+		{name: "crc8", opts: []Option{WithCRC8SMBus()}},
+		{name: "crc32", opts: []Option{WithCRC32()}},
+		{name: "xxhash", opts: []Option{WithXXHash()}},
+		{name: "hmac-sha256", opts: []Option{WithHMACSha256([]byte("key"))}},
+		{name: "argon2id", opts: []Option{WithArgon2id([]byte("saltsalt"), 1, 8*1024, 1, 32)}},
+		{name: "user-defined", opts: []Option{WithUserDifinedAlgorithm(&md5sumHasher{})}},
+		{name: "decorated", opts: []Option{WithSha256(), WithTrimSpace()}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHash(tt.opts...)
+			want, err := h.Generate("test")
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			got, err := h.Generate([]byte("test"))
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Hash.Generate([]byte) = %x, want %x", got, want)
+			}
+			if bh, ok := h.hasher.(BytesHasher); ok {
+				got, err := bh.GenHashFromBytes([]byte("test"))
+				if err != nil {
+					t.Fatalf("BytesHasher.GenHashFromBytes() error = %v", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("BytesHasher.GenHashFromBytes() = %x, want %x", got, want)
+				}
+			}
+			if err := h.Compare(want, []byte("test")); err != nil {
+				t.Errorf("Hash.Compare() error = %v", err)
+			}
+			if err := h.Compare(want, []byte("tesT")); !errors.Is(err, ErrHashMismatch) {
+				t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+			}
+		})
+	}
+
+	t.Run("bcrypt password", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithBcrypt(bcrypt.MinCost))
+		hash, err := h.Generate([]byte("password"))
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if err := h.Compare(hash, "password"); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if err := h.Compare(hash, []byte("wrong")); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+	})
+}
+
+func TestHash_GenerateWithProgress(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join("testdata", "test.txt")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input func(t *testing.T) any
+		want  int64
+	}{
+		{
+			name: "io.Reader",
+			input: func(t *testing.T) any {
+				f, err := os.Open(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { f.Close() })
+				// iotest.OneByteReader makes the callback be called for every byte.
+				return iotest.OneByteReader(f)
+			},
+			want: info.Size(),
+		},
+		{
+			name:  "ReaderFunc",
+			input: func(_ *testing.T) any { return openFile(path) },
+			want:  info.Size(),
+		},
+		{
+			name:  "[]byte",
+			input: func(_ *testing.T) any { return []byte("test") },
+			want:  4,
+		},
+		{
+			name:  "string",
+			input: func(_ *testing.T) any { return "test" },
+			want:  4,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls int
+			var last int64
+			h := NewHash(WithSha256())
+			got, err := h.GenerateWithProgress(tt.input(t), func(n int64) {
+				if n < last {
+					t.Errorf("bytesRead = %d after %d, want it not to decrease", n, last)
+				}
+				calls++
+				last = n
+			})
+			if err != nil {
+				t.Fatalf("Hash.GenerateWithProgress() error = %v", err)
+			}
+			if last != tt.want {
+				t.Errorf("final bytesRead = %d, want %d", last, tt.want)
+			}
+			if calls == 0 {
+				t.Error("callback was not called")
+			}
+
+			want, err := h.Generate(tt.input(t))
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Hash.GenerateWithProgress() = %x, want %x", got, want)
+			}
+		})
+	}
+
+	t.Run("retried seekable reader", func(t *testing.T) {
+		t.Parallel()
+
+		want := sha256.Sum256([]byte("test"))
+		var last int64
+		got, err := NewHash(WithSha256(), WithRetry(1)).GenerateWithProgress(
+			&flakyReader{r: bytes.NewReader([]byte("test")), failAfter: 2},
+			func(n int64) { last = n },
+		)
+		if err != nil {
+			t.Fatalf("Hash.GenerateWithProgress() error = %v", err)
+		}
+		if !bytes.Equal(got, want[:]) {
+			t.Errorf("Hash.GenerateWithProgress() = %x, want %x", got, want)
+		}
+		if last != 4 {
+			t.Errorf("final bytesRead = %d, want 4", last)
+		}
+	})
+}
+
+func TestHash_WriterWithTeeReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hash while copying a file with io.TeeReader", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		w, err := h.Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+
+		f, err := os.Open(filepath.Join("testdata", "test.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		var copied bytes.Buffer
+		if _, err := io.Copy(&copied, io.TeeReader(f, w)); err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := h.GenerateFile(filepath.Join("testdata", "test.txt"))
+		if err != nil {
+			t.Fatalf("Hash.GenerateFile() error = %v", err)
+		}
+		if got := w.Sum(); !bytes.Equal(got, want) {
+			t.Errorf("DigestWriter.Sum() = %x, want %x", got, want)
+		}
+		if err := h.Compare(want, &copied); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+	})
+
+	t.Run("not streamable", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewHash(WithPhash()).Writer(); !errors.Is(err, ErrStreamingNotSupported) {
+			t.Errorf("Hash.Writer() error = %v, want %v", err, ErrStreamingNotSupported)
+		}
+	})
+}
+
+func TestHash_GenerateWithBlake3Modes(t *testing.T) {
+	t.Parallel()
+
+	unkeyed, err := NewHash(WithBlake3()).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	t.Run("keyed", func(t *testing.T) {
+		t.Parallel()
+
+		var key [32]byte
+		for i := range key {
+			key[i] = byte(i)
+		}
+		h := NewHash(WithBlake3Keyed(key))
+		if got := h.Algorithm(); got != "blake3-keyed" {
+			t.Errorf("Hash.Algorithm() = %s, want blake3-keyed", got)
+		}
+		got, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if len(got) != 64 {
+			t.Errorf("len(Hash.Generate()) = %d, want 64", len(got))
+		}
+		if bytes.Equal(got, unkeyed) {
+			t.Errorf("keyed hash equals the unkeyed hash %x", got)
+		}
+		if err := h.Compare(got, strings.NewReader("test")); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if err := NewHash(WithBlake3Keyed([32]byte{})).Compare(got, "test"); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+		}
+	})
+
+	t.Run("derive key", func(t *testing.T) {
+		t.Parallel()
+
+		const want = "0f2878398f63bbabba509e2e816c0f9280315a1d36fc0107c2ce50d5adec86ce" +
+			"7f989d56fe32bc219c9d20932b2e088ac69ca0ac5629a671922ffa387b687330"
+
+		h := NewHash(WithBlake3DeriveKey("github.com/nao1215/hasher test context"))
+		if got := h.Algorithm(); got != "blake3-derive-key" {
+			t.Errorf("Hash.Algorithm() = %s, want blake3-derive-key", got)
+		}
+		for _, input := range []any{"test", []byte("test"), strings.NewReader("test")} {
+			got, err := h.Generate(input)
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != want {
+				t.Errorf("Hash.Generate(%T) = %x, want %s", input, got, want)
+			}
+		}
+
+		other, err := NewHash(WithBlake3DeriveKey("another context")).Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if hex.EncodeToString(other) == want {
+			t.Error("keys derived for different contexts are equal")
+		}
+	})
+}
+
+func TestHash_GenerateWithShakeInvalidLength(t *testing.T) {
+	t.Parallel()
+
+	for _, opt := range []Option{WithShake128(0), WithShake256(-1)} {
+		h := NewHash(opt)
+		if _, err := h.Generate("test"); !errors.Is(err, ErrInvalidOutputLength) {
+			t.Errorf("Hash.Generate() error = %v, want %v", err, ErrInvalidOutputLength)
+		}
+		if err := h.Compare(nil, strings.NewReader("test")); !errors.Is(err, ErrInvalidOutputLength) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrInvalidOutputLength)
+		}
+	}
+}
+
+func TestHash_CompareHex(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		hexStr      string
+		input       any
+		expectedErr error
+	}{
+		{name: "lowercase", hexStr: "098f6bcd4621d373cade4e832627b4f6", input: "test", expectedErr: nil},
+		{name: "uppercase with whitespace", hexStr: "  098F6BCD4621D373CADE4E832627B4F6 ", input: "test", expectedErr: nil},
+		{name: "trailing newline", hexStr: "098f6bcd4621d373cade4e832627b4f6\n", input: strings.NewReader("test"), expectedErr: nil},
+		{name: "mismatch", hexStr: "098F6BCD4621D373CADE4E832627B4F6", input: "tesT", expectedErr: ErrHashMismatch},
+		{name: "not hex", hexStr: "098F6BCD4621D373CADE4E832627B4FZ", input: "test", expectedErr: ErrInvalidHexDigest},
+		{name: "odd length", hexStr: "098", input: "test", expectedErr: ErrInvalidHexDigest},
+		{name: "inner whitespace", hexStr: "098f 6bcd", input: "test", expectedErr: ErrInvalidHexDigest},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := NewHash().CompareHex(tt.hexStr, tt.input); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Hash.CompareHex() error = %v, want %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestDigestWriter_SaveState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resume sha256", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		w, err := h.Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+		if _, err := io.WriteString(w, "te"); err != nil {
+			t.Fatal(err)
+		}
+		state, err := w.SaveState()
+		if err != nil {
+			t.Fatalf("DigestWriter.SaveState() error = %v", err)
+		}
+
+		resumed, err := h.Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+		if err := resumed.RestoreState(state); err != nil {
+			t.Fatalf("DigestWriter.RestoreState() error = %v", err)
+		}
+		if _, err := io.WriteString(resumed, "st"); err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if got := resumed.Sum(); !bytes.Equal(got, want) {
+			t.Errorf("DigestWriter.Sum() = %x, want %x", got, want)
+		}
+	})
+
+	t.Run("state of another algorithm", func(t *testing.T) {
+		t.Parallel()
+
+		w, err := NewHash(WithSha256()).Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+		state, err := w.SaveState()
+		if err != nil {
+			t.Fatalf("DigestWriter.SaveState() error = %v", err)
+		}
+
+		other, err := NewHash(WithSha512()).Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+		if err := other.RestoreState(state); err == nil {
+			t.Error("DigestWriter.RestoreState() error = nil, want an error")
+		}
+	})
+
+	t.Run("not supported", func(t *testing.T) {
+		t.Parallel()
+
+		w, err := NewHash(WithCRC8SMBus()).Writer()
+		if err != nil {
+			t.Fatalf("Hash.Writer() error = %v", err)
+		}
+		if _, err := w.SaveState(); !errors.Is(err, ErrStateNotSupported) {
+			t.Errorf("DigestWriter.SaveState() error = %v, want %v", err, ErrStateNotSupported)
+		}
+		if err := w.RestoreState(nil); !errors.Is(err, ErrStateNotSupported) {
+			t.Errorf("DigestWriter.RestoreState() error = %v, want %v", err, ErrStateNotSupported)
+		}
+	})
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("slow reader", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithTimeout(50*time.Millisecond))
+		for name, generate := range map[string]func(input any) ([]byte, error){
+			"Hash.Generate()":       h.Generate,
+			"Hash.GenerateReused()": h.GenerateReused,
+		} {
+			done := make(chan error, 1)
+			go func() {
+				_, err := generate(&slowReader{delay: time.Millisecond})
+				done <- err
+			}()
+
+			select {
+			case err := <-done:
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("%s error = %v, want %v", name, err, context.DeadlineExceeded)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s did not return after the timeout", name)
+			}
+		}
+	})
+
+	t.Run("within the timeout", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256(), WithTimeout(time.Minute))
+		want, err := NewHash(WithSha256()).Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		for _, input := range []func() any{
+			func() any { return "test" },
+			func() any { return strings.NewReader("test") },
+		} {
+			got, err := h.Generate(input())
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Hash.Generate() = %x, want %x", got, want)
+			}
+			if got, err = h.GenerateReused(input()); err != nil || !bytes.Equal(got, want) {
+				t.Errorf("Hash.GenerateReused() = %x, %v, want %x", got, err, want)
+			}
+		}
+	})
+}
+
+func TestHash_GenerateBatch(t *testing.T) {
+	t.Parallel()
+
+	// Readers cannot be shared by the subtests, so each one gets its own inputs.
+	newInputs := func() []any {
+		return []any{"a", "b", 42, "c", strings.NewReader("a")}
+	}
+	want := []string{
+		"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		"3e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d",
+		"",
+		"2e7d2c03a9507ae265ecf5b5356885a53393a2029d241394997265a1a25aefc6",
+		"ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+	}
+
+	for _, concurrency := range []int{0, 1, 3, 16} {
+		concurrency := concurrency
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			t.Parallel()
+
+			in := newInputs()
+			digests, errs := NewHash(WithSha256(), WithConcurrency(concurrency)).GenerateBatch(in)
+			if len(digests) != len(in) || len(errs) != len(in) {
+				t.Fatalf("Hash.GenerateBatch() returned %d hashes and %d errors, want %d", len(digests), len(errs), len(in))
+			}
+			for i := range in {
+				if want[i] == "" {
+					if !errors.Is(errs[i], ErrUnsupportedInputType) {
+						t.Errorf("errs[%d] = %v, want %v", i, errs[i], ErrUnsupportedInputType)
+					}
+					continue
+				}
+				if errs[i] != nil {
+					t.Errorf("errs[%d] = %v", i, errs[i])
+				}
+				if got := hex.EncodeToString(digests[i]); got != want[i] {
+					t.Errorf("digests[%d] = %s, want %s", i, got, want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		digests, errs := NewHash(WithConcurrency(4)).GenerateBatch(nil)
+		if len(digests) != 0 || len(errs) != 0 {
+			t.Errorf("Hash.GenerateBatch() = %v, %v, want empty results", digests, errs)
+		}
+	})
+}
+
+func TestChunker(t *testing.T) {
+	t.Parallel()
+
+	const minSize, maxSize, avgSize = 256, 4096, 1024
+	data := make([]byte, 256*1024)
+	rand.New(rand.NewSource(1)).Read(data) //nolint:gosec
+
+	chunks := func(t *testing.T, data []byte) [][]byte {
+		t.Helper()
+
+		h := NewHash(WithSha256())
+		c, err := NewChunker(h, bytes.NewReader(data), minSize, maxSize, avgSize)
+		if err != nil {
+			t.Fatalf("NewChunker() error = %v", err)
+		}
+		var chunks [][]byte
+		for {
+			chunk, digest, err := c.Next()
+			if errors.Is(err, io.EOF) {
+				return chunks
+			}
+			if err != nil {
+				t.Fatalf("Chunker.Next() error = %v", err)
+			}
+			want, err := h.Generate(chunk)
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if !bytes.Equal(digest, want) {
+				t.Errorf("Chunker.Next() digest = %x, want %x", digest, want)
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	t.Run("boundaries", func(t *testing.T) {
+		t.Parallel()
+
+		got := chunks(t, data)
+		if !bytes.Equal(bytes.Join(got, nil), data) {
+			t.Fatal("the chunks do not add up to the input")
+		}
+		for i, chunk := range got {
+			if len(chunk) > maxSize || (len(chunk) < minSize && i != len(got)-1) {
+				t.Errorf("len(chunk %d) = %d, want %d to %d", i, len(chunk), minSize, maxSize)
+			}
+		}
+		if n := len(data) / len(got); n < minSize+avgSize/2 || n > minSize+avgSize*2 {
+			t.Errorf("average chunk size = %d, want about %d", n, minSize+avgSize)
+		}
+
+		// The boundaries must not change between versions, or stored chunks could no longer be deduplicated.
+		for i, want := range []int{827, 551, 1116, 1231, 1876, 1103, 2400, 507} {
+			if len(got[i]) != want {
+				t.Errorf("len(chunk %d) = %d, want %d", i, len(got[i]), want)
+			}
+		}
+
+		again := chunks(t, data)
+		if len(again) != len(got) {
+			t.Fatalf("got %d chunks, then %d chunks", len(got), len(again))
+		}
+		for i := range got {
+			if !bytes.Equal(got[i], again[i]) {
+				t.Errorf("chunk %d changed between runs", i)
+			}
+		}
+	})
+
+	t.Run("edit only changes nearby chunks", func(t *testing.T) {
+		t.Parallel()
+
+		edited := append([]byte("inserted"), data...)
+		seen := make(map[string]bool)
+		for _, chunk := range chunks(t, data) {
+			seen[string(chunk)] = true
+		}
+		got := chunks(t, edited)
+		shared := 0
+		for _, chunk := range got {
+			if seen[string(chunk)] {
+				shared++
+			}
+		}
+		if shared < len(got)-3 {
+			t.Errorf("%d of %d chunks are shared after an insertion at the start, want all but a few", shared, len(got))
+		}
+	})
+
+	t.Run("invalid sizes", func(t *testing.T) {
+		t.Parallel()
+
+		for _, sizes := range [][3]int{{0, 10, 5}, {10, 20, 5}, {10, 20, 30}} {
+			if _, err := NewChunker(NewHash(), strings.NewReader(""), sizes[0], sizes[1], sizes[2]); !errors.Is(err, ErrInvalidChunkSize) {
+				t.Errorf("NewChunker(%v) error = %v, want %v", sizes, err, ErrInvalidChunkSize)
+			}
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewChunker(NewHash(), strings.NewReader(""), minSize, maxSize, avgSize)
+		if err != nil {
+			t.Fatalf("NewChunker() error = %v", err)
+		}
+		if _, _, err := c.Next(); !errors.Is(err, io.EOF) {
+			t.Errorf("Chunker.Next() error = %v, want %v", err, io.EOF)
+		}
+	})
+}
+
+func TestHash_TreeHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("root of three leaves", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		root, leaves, err := h.TreeHash(strings.NewReader("abcdefghij"), 4)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		// sha256(0x01 || sha256(0x01 || sha256(0x00 || "abcd") || sha256(0x00 || "efgh")) || sha256(0x00 || "ij"))
+		if got := hex.EncodeToString(root); got != "2a5b33d54d89d05737a7dd798d9862d55951564aafb5460691ad8a7a9ab6c678" {
+			t.Errorf("Hash.TreeHash() root = %s", got)
+		}
+		if len(leaves) != 3 {
+			t.Fatalf("len(leaves) = %d, want 3", len(leaves))
+		}
+		for i, block := range []string{"abcd", "efgh", "ij"} {
+			if err := h.Compare(leaves[i], "\x00"+block); err != nil {
+				t.Errorf("leaf %d: Hash.Compare() error = %v", i, err)
+			}
+		}
+	})
+
+	t.Run("stable root and sensitivity to each leaf", func(t *testing.T) {
+		t.Parallel()
+
+		data := bytes.Repeat([]byte("0123456789"), 1000)
+		h := NewHash(WithBlake3())
+		root, _, err := h.TreeHash(bytes.NewReader(data), 1024)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		again, _, err := h.TreeHash(bytes.NewReader(data), 1024)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		if !bytes.Equal(root, again) {
+			t.Errorf("Hash.TreeHash() root changed between runs: %x, %x", root, again)
+		}
+
+		other, _, err := h.TreeHash(bytes.NewReader(data), 512)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		if bytes.Equal(root, other) {
+			t.Error("Hash.TreeHash() root does not depend on the leaf size")
+		}
+
+		for _, offset := range []int{0, 5000, len(data) - 1} {
+			changed := bytes.Clone(data)
+			changed[offset] ^= 1
+			got, _, err := h.TreeHash(bytes.NewReader(changed), 1024)
+			if err != nil {
+				t.Fatalf("Hash.TreeHash() error = %v", err)
+			}
+			if bytes.Equal(got, root) {
+				t.Errorf("Hash.TreeHash() root did not change after changing byte %d", offset)
+			}
+		}
+	})
+
+	t.Run("leaf and node domain separation", func(t *testing.T) {
+		t.Parallel()
+
+		// With a leaf size of two digests, a one-block input holding two leaves must not
+		// have the same root as the two-block input those leaves come from.
+		h := NewHash(WithSha256())
+		leafSize := 2 * sha256.Size
+		root, leaves, err := h.TreeHash(bytes.NewReader(bytes.Repeat([]byte("x"), 2*leafSize)), leafSize)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		forged, _, err := h.TreeHash(bytes.NewReader(append(bytes.Clone(leaves[0]), leaves[1]...)), leafSize)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		if bytes.Equal(root, forged) {
+			t.Errorf("Hash.TreeHash() root %x of the concatenated leaves equals the root of the original input", root)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewHash(WithSha256())
+		root, leaves, err := h.TreeHash(strings.NewReader(""), 4)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		if err := h.Compare(root, ""); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if len(leaves) != 0 {
+			t.Errorf("len(leaves) = %d, want 0", len(leaves))
+		}
+	})
+
+	t.Run("checkpoint is not saved per leaf", func(t *testing.T) {
+		t.Parallel()
+
+		// The directory of the checkpoint does not exist, so saving a checkpoint would fail.
+		path := filepath.Join(t.TempDir(), "missing", "checkpoint")
+		data := strings.Repeat("a", 10)
+		root, leaves, err := NewHash(WithSha256(), WithCheckpoint(path, 1)).TreeHash(strings.NewReader(data), 4)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		wantRoot, wantLeaves, err := NewHash(WithSha256()).TreeHash(strings.NewReader(data), 4)
+		if err != nil {
+			t.Fatalf("Hash.TreeHash() error = %v", err)
+		}
+		if !bytes.Equal(root, wantRoot) || !reflect.DeepEqual(leaves, wantLeaves) {
+			t.Errorf("Hash.TreeHash() = %x, %x, want %x, %x", root, leaves, wantRoot, wantLeaves)
+		}
+	})
+
+	t.Run("invalid leaf size", func(t *testing.T) {
+		t.Parallel()
+
+		if _, _, err := NewHash().TreeHash(strings.NewReader("test"), 0); !errors.Is(err, ErrInvalidLeafSize) {
+			t.Errorf("Hash.TreeHash() error = %v, want %v", err, ErrInvalidLeafSize)
+		}
+	})
+}
+
+func TestHash_TreeHashAt(t *testing.T) {
+	t.Parallel()
+
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	path := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+
+	for _, concurrency := range []int{0, 1, 4} {
+		h := NewHash(WithSha256(), WithConcurrency(concurrency))
+		for _, leafSize := range []int{1024, 1000, 20000} {
+			wantRoot, wantLeaves, err := h.TreeHash(bytes.NewReader(data), leafSize)
+			if err != nil {
+				t.Fatalf("Hash.TreeHash() error = %v", err)
+			}
+			root, leaves, err := h.TreeHashAt(f, int64(len(data)), leafSize)
+			if err != nil {
+				t.Fatalf("Hash.TreeHashAt() error = %v", err)
+			}
+			if !bytes.Equal(root, wantRoot) {
+				t.Errorf("Hash.TreeHashAt(concurrency %d, leaf size %d) root = %x, want %x", concurrency, leafSize, root, wantRoot)
+			}
+			if len(leaves) != len(wantLeaves) {
+				t.Fatalf("Hash.TreeHashAt(concurrency %d, leaf size %d) returned %d leaves, want %d", concurrency, leafSize, len(leaves), len(wantLeaves))
+			}
+			for i := range leaves {
+				if !bytes.Equal(leaves[i], wantLeaves[i]) {
+					t.Errorf("Hash.TreeHashAt(concurrency %d, leaf size %d) leaf %d = %x, want %x", concurrency, leafSize, i, leaves[i], wantLeaves[i])
+				}
+			}
+		}
+	}
+
+	h := NewHash(WithSha256())
+	root, leaves, err := h.TreeHashAt(f, 0, 1024)
+	if err != nil {
+		t.Fatalf("Hash.TreeHashAt() error = %v", err)
+	}
+	if err := h.Compare(root, ""); err != nil || len(leaves) != 0 {
+		t.Errorf("Hash.TreeHashAt() of empty input: Hash.Compare() error = %v, %d leaves", err, len(leaves))
+	}
+	if _, _, err := h.TreeHashAt(f, int64(len(data))+1, 1024); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Hash.TreeHashAt() error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, _, err := h.TreeHashAt(f, int64(len(data)), 0); !errors.Is(err, ErrInvalidLeafSize) {
+		t.Errorf("Hash.TreeHashAt() error = %v, want %v", err, ErrInvalidLeafSize)
+	}
+}
+
+func TestWithTruncate(t *testing.T) {
+	t.Parallel()
+
+	full, err := NewHash(WithSha256()).Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+
+	h := NewHash(WithSha256(), WithTruncate(8))
+	for _, input := range []func() any{
+		func() any { return "test" },
+		func() any { return strings.NewReader("test") },
+	} {
+		got, err := h.Generate(input())
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if !bytes.Equal(got, full[:8]) {
+			t.Errorf("Hash.Generate() = %x, want %x", got, full[:8])
+		}
+		if err := h.Compare(full[:8], input()); err != nil {
+			t.Errorf("Hash.Compare() error = %v", err)
+		}
+		if err := h.Compare(full, input()); !errors.Is(err, ErrInvalidHashLength) {
+			t.Errorf("Hash.Compare() error = %v, want %v", err, ErrInvalidHashLength)
+		}
+	}
+	if err := h.Compare(full[:8], "mismatch"); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Hash.Compare() error = %v, want %v", err, ErrHashMismatch)
+	}
+
+	for _, n := range []int{0, -1, 33} {
+		h := NewHash(WithSha256(), WithTruncate(n))
+		if _, err := h.Generate("test"); !errors.Is(err, ErrInvalidTruncation) {
+			t.Errorf("Hash.Generate() with WithTruncate(%d) error = %v, want %v", n, err, ErrInvalidTruncation)
+		}
+		if err := h.Compare(full, "test"); !errors.Is(err, ErrInvalidTruncation) {
+			t.Errorf("Hash.Compare() with WithTruncate(%d) error = %v, want %v", n, err, ErrInvalidTruncation)
+		}
+	}
 }
@@ -0,0 +1,19 @@
+package hasher
+
+import (
+	"hash"
+
+	"github.com/minio/highwayhash"
+)
+
+// newHighwayHasher creates a new Hasher instance for HighwayHash-256 keyed with key.
+func newHighwayHasher(key [32]byte) Hasher {
+	return &hasher{
+		name: "highwayhash",
+		HashFunc: func() hash.Hash {
+			// highwayhash.New only fails if the key is not 32 bytes long.
+			h, _ := highwayhash.New(key[:]) //nolint:errcheck
+			return h
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// imageHashSize is the length of an average hash or a difference hash in bytes.
+const imageHashSize = 8
+
+// gridHasher is a Hasher for the perceptual hashes computed from a downscaled grayscale image,
+// i.e. average hash (aHash) and difference hash (dHash).
+type gridHasher struct {
+	name string
+	// hashFunc computes the 64-bit hash of an image.
+	hashFunc func(img image.Image) uint64
+}
+
+// newAverageHasher creates a new Hasher instance for average hash.
+func newAverageHasher() Hasher {
+	return &gridHasher{name: "ahash", hashFunc: averageHash}
+}
+
+// newDifferenceHasher creates a new Hasher instance for difference hash.
+func newDifferenceHasher() Hasher {
+	return &gridHasher{name: "dhash", hashFunc: differenceHash}
+}
+
+// Name returns the name of the algorithm.
+func (g *gridHasher) Name() string {
+	return g.name
+}
+
+// size returns the length of a hash.
+func (g *gridHasher) size() int {
+	return imageHashSize
+}
+
+// GenHashFromString always returns ErrPhashNotSupportedString because perceptual hashing does not support string input.
+func (g *gridHasher) GenHashFromString(_ string) ([]byte, error) {
+	return nil, ErrPhashNotSupportedString
+}
+
+// CmpHashAndString always returns ErrPhashNotSupportedString because perceptual hashing does not support string input.
+func (g *gridHasher) CmpHashAndString(_ []byte, _ string) error {
+	return ErrPhashNotSupportedString
+}
+
+// GenHashFromIOReader decodes an image from an io.Reader and generates its hash.
+func (g *gridHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	return g.genHashFromImage(img)
+}
+
+// genHashFromImage generates a hash from a decoded image.
+func (g *gridHasher) genHashFromImage(img image.Image) ([]byte, error) {
+	if img == nil || img.Bounds().Empty() {
+		return nil, fmt.Errorf("%w: decoded image is empty", ErrInvalidImage)
+	}
+	hashBytes := make([]byte, imageHashSize)
+	binary.LittleEndian.PutUint64(hashBytes, g.hashFunc(img))
+	return hashBytes, nil
+}
+
+// CmpHashAndIOReader compares a hash and the hash of the image decoded from an io.Reader.
+func (g *gridHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := g.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// grayscale returns img scaled to width x height in grayscale.
+func grayscale(img image.Image, width, height int) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, width, height))
+	draw.BiLinear.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// averageHash computes the average hash of img: the image is reduced to 8x8 grayscale pixels,
+// and each bit is set if the pixel is brighter than the mean.
+func averageHash(img image.Image) uint64 {
+	g := grayscale(img, 8, 8)
+
+	sum := 0
+	for _, p := range g.Pix {
+		sum += int(p)
+	}
+	mean := sum / len(g.Pix)
+
+	var hash uint64
+	for i, p := range g.Pix {
+		if int(p) > mean {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+// differenceHash computes the difference hash of img: the image is reduced to 9x8 grayscale pixels,
+// and each bit is set if a pixel is brighter than its right neighbor.
+func differenceHash(img image.Image) uint64 {
+	g := grayscale(img, 9, 8)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if g.GrayAt(x, y).Y > g.GrayAt(x+1, y).Y {
+				hash |= 1 << uint(y*8+x)
+			}
+		}
+	}
+	return hash
+}
@@ -1,6 +1,11 @@
 package hasher
 
-import "io"
+import (
+	"fmt"
+	"hash"
+	"image"
+	"io"
+)
 
 // Hasher is an interface that contains the methods to generate and compare hashes.
 type Hasher interface {
@@ -15,3 +20,102 @@ type Hasher interface {
 	// If the hash and the io.Reader are the same, nil is returned.
 	CmpHashAndIOReader([]byte, io.Reader) error
 }
+
+// BytesHasher is an optional interface implemented by hashers that can hash a []byte directly.
+// The built-in hashers implement it. For a Hasher that does not, Generate and Compare hash a []byte
+// as an io.Reader over its content.
+type BytesHasher interface {
+	// GenHashFromBytes generates a hash from a byte slice.
+	GenHashFromBytes([]byte) ([]byte, error)
+	// CmpHashAndBytes compares a hash and a byte slice.
+	// If the hash and the byte slice are the same, nil is returned.
+	CmpHashAndBytes([]byte, []byte) error
+}
+
+// streamer is implemented by hashers that are backed by a hash.Hash.
+// It lets Hash drive the underlying hash directly, e.g. to control how an io.Reader is buffered.
+type streamer interface {
+	// newHash returns a new hash.Hash for the algorithm.
+	newHash() hash.Hash
+}
+
+// imageHasher is implemented by hashers that can hash a decoded image.Image, e.g. perceptual hash.
+// It lets Generate and Compare hash an image.Image without encoding and decoding it again.
+type imageHasher interface {
+	// genHashFromImage generates a hash from an image.
+	genHashFromImage(image.Image) ([]byte, error)
+}
+
+// hashImage generates a hash from a decoded image with hs.
+// If hs cannot hash an image.Image, ErrUnsupportedInputType is returned.
+func hashImage(hs Hasher, img image.Image) ([]byte, error) {
+	ih, ok := hs.(imageHasher)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedInputType, img)
+	}
+	return ih.genHashFromImage(img)
+}
+
+// randomizedHasher is implemented by hashers whose hashes embed a random salt, e.g. bcrypt,
+// so hashing the same input twice yields different hashes.
+type randomizedHasher interface {
+	// randomized marks the hasher as randomized.
+	randomized()
+}
+
+// wrapper is implemented by hashers that wrap another hasher, e.g. the options that transform the input.
+type wrapper interface {
+	// unwrap returns the wrapped hasher.
+	unwrap() Hasher
+}
+
+// isRandomized reports whether hs, or a hasher wrapped by it, is randomized.
+func isRandomized(hs Hasher) bool {
+	for {
+		if _, ok := hs.(randomizedHasher); ok {
+			return true
+		}
+		w, ok := hs.(wrapper)
+		if !ok {
+			return false
+		}
+		hs = w.unwrap()
+	}
+}
+
+// Named is an optional interface implemented by hashers that know the name of their algorithm.
+// A user-defined Hasher can implement Named to report its name through Hash.Algorithm.
+type Named interface {
+	// Name returns the name of the algorithm.
+	Name() string
+}
+
+// userDefinedAlgorithmName is the algorithm name of hashers that do not implement Name().
+const userDefinedAlgorithmName = "user-defined"
+
+// algorithmName returns the name of the algorithm of hs.
+// If hs does not implement Named, "user-defined" is returned.
+func algorithmName(hs Hasher) string {
+	if n, ok := hs.(Named); ok {
+		return n.Name()
+	}
+	return userDefinedAlgorithmName
+}
+
+// sizer is implemented by hashers that are not backed by a hash.Hash but know the length of their hashes.
+type sizer interface {
+	// size returns the length of a hash in bytes, or 0 if it is not fixed.
+	size() int
+}
+
+// digestSize returns the length in bytes of the hashes generated by hs, or 0 if it is unknown or not fixed.
+func digestSize(hs Hasher) int {
+	switch v := hs.(type) {
+	case sizer:
+		return v.size()
+	case streamer:
+		return v.newHash().Size()
+	default:
+		return 0
+	}
+}
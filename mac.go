@@ -0,0 +1,145 @@
+package hasher
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// lengthExtendableAlgorithms is the set of algorithms built on the Merkle–Damgård construction
+// whose full internal state is exposed as the digest.
+var lengthExtendableAlgorithms = map[string]bool{
+	"md5":       true,
+	"sha1":      true,
+	"sha256":    true,
+	"sha512":    true,
+	"ripemd160": true,
+	"whirlpool": true,
+}
+
+// IsLengthExtendable reports whether the configured algorithm is vulnerable to length extension attacks.
+// For a Merkle–Damgård hash such as MD5, SHA-1, SHA-256, SHA-512, or RIPEMD-160, the digest of
+// secret || message lets an attacker compute the digest of secret || message || padding || suffix
+// without knowing the secret, so H(secret || message) must not be used as a MAC. Sponge and tree based hashes such as
+// SHA-3 and BLAKE are not affected. Use HardenedMAC to authenticate messages with any algorithm.
+// A digest shortened by WithTruncate does not expose the full state, so it is not length-extendable.
+func (h *Hash) IsLengthExtendable() bool {
+	hs := h.hasher
+	for {
+		if t, ok := hs.(*truncateHasher); ok && t.n < digestSize(t.base) {
+			return false
+		}
+		w, ok := hs.(wrapper)
+		if !ok {
+			return lengthExtendableAlgorithms[algorithmName(hs)]
+		}
+		hs = w.unwrap()
+	}
+}
+
+// HardenedMAC returns the HMAC of message using key and the configured algorithm.
+// HMAC is not affected by length extension, even with a length-extendable algorithm.
+// If the configured algorithm is not backed by a hash.Hash, ErrStreamingNotSupported is returned.
+func (h *Hash) HardenedMAC(key, message []byte) ([]byte, error) {
+	s, ok := h.hasher.(streamer)
+	if !ok {
+		return nil, fmt.Errorf("%w: HMAC needs an algorithm backed by hash.Hash", ErrStreamingNotSupported)
+	}
+
+	mac := hmac.New(s.newHash, key)
+	if _, err := mac.Write(message); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+// hmacHasher is a Hasher that computes the HMAC of the input.
+// Hashes are compared with hmac.Equal, which runs in constant time.
+type hmacHasher struct {
+	name     string
+	key      []byte
+	HashFunc func() hash.Hash
+}
+
+// newHMACHasher creates a new Hasher instance for HMAC with the given key and hash function.
+func newHMACHasher(name string, key []byte, fn func() hash.Hash) Hasher {
+	return &hmacHasher{name: name, key: bytes.Clone(key), HashFunc: fn}
+}
+
+// Name returns the name of the algorithm.
+func (m *hmacHasher) Name() string {
+	return m.name
+}
+
+// newHash returns a new hash.Hash computing the HMAC.
+func (m *hmacHasher) newHash() hash.Hash {
+	return hmac.New(m.HashFunc, m.key)
+}
+
+// GenHashFromString generates the HMAC of a string.
+func (m *hmacHasher) GenHashFromString(s string) ([]byte, error) {
+	mac := m.newHash()
+	if _, err := mac.Write([]byte(s)); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+// GenHashFromBytes generates the HMAC of a byte slice.
+func (m *hmacHasher) GenHashFromBytes(b []byte) ([]byte, error) {
+	mac := m.newHash()
+	if _, err := mac.Write(b); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and the HMAC of a byte slice in constant time.
+func (m *hmacHasher) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := m.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(hashA, hashB) {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// GenHashFromIOReader generates the HMAC of an io.Reader.
+func (m *hmacHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	mac := m.newHash()
+	if _, err := copyBuffer(mac, r, 0); err != nil {
+		return nil, err
+	}
+	return mac.Sum(nil), nil
+}
+
+// CmpHashAndString compares a hash and the HMAC of a string in constant time.
+func (m *hmacHasher) CmpHashAndString(hashA []byte, s string) error {
+	hashB, err := m.GenHashFromString(s)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(hashA, hashB) {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader compares a hash and the HMAC of an io.Reader in constant time.
+func (m *hmacHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := m.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(hashA, hashB) {
+		return ErrHashMismatch
+	}
+	return nil
+}
@@ -1,13 +1,24 @@
 package hasher
 
 import (
-	"bytes"
 	"crypto/md5" //nolint:gosec
+	"crypto/subtle"
+	"hash"
 	"io"
 )
 
 type md5sumHasher struct{}
 
+// Name returns the name of the algorithm.
+func (m *md5sumHasher) Name() string {
+	return "md5"
+}
+
+// newHash returns a new hash.Hash for the md5sum algorithm.
+func (m *md5sumHasher) newHash() hash.Hash {
+	return md5.New() //nolint:gosec
+}
+
 // GenHashFromString generates a hash from a string using the md5sum algorithm.
 func (m *md5sumHasher) GenHashFromString(s string) ([]byte, error) {
 	h := md5.New() //nolint:gosec
@@ -17,10 +28,32 @@ func (m *md5sumHasher) GenHashFromString(s string) ([]byte, error) {
 	return h.Sum(nil), nil
 }
 
+// GenHashFromBytes generates a hash from a byte slice using the md5sum algorithm.
+func (m *md5sumHasher) GenHashFromBytes(b []byte) ([]byte, error) {
+	h := md5.New() //nolint:gosec
+	if _, err := h.Write(b); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// CmpHashAndBytes compares a hash and a byte slice using the md5sum algorithm.
+func (m *md5sumHasher) CmpHashAndBytes(hashA []byte, b []byte) error {
+	hashB, err := m.GenHashFromBytes(b)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
 // GenHashFromIOReader generates a hash from an io.Reader using the md5sum algorithm.
 func (m *md5sumHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
 	h := md5.New() //nolint:gosec
-	if _, err := io.Copy(h, r); err != nil {
+	if _, err := copyBuffer(h, r, 0); err != nil {
 		return nil, err
 	}
 	return h.Sum(nil), nil
@@ -33,7 +66,7 @@ func (m *md5sumHasher) CmpHashAndString(hashA []byte, s string) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
@@ -46,7 +79,7 @@ func (m *md5sumHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
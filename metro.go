@@ -0,0 +1,30 @@
+package hasher
+
+import (
+	"encoding/binary"
+
+	metro "github.com/dgryski/go-metro"
+)
+
+// newMetroHash64Hasher creates a new Hasher instance for MetroHash64 with seed 0.
+// The hash is encoded in big-endian order.
+func newMetroHash64Hasher() Hasher {
+	return &hasher{
+		name: "metrohash64",
+		HashFunc: newOneShotHash(8, func(b []byte) []byte {
+			return binary.BigEndian.AppendUint64(nil, metro.Hash64(b, 0))
+		}),
+	}
+}
+
+// newMetroHash128Hasher creates a new Hasher instance for MetroHash128 with seed 0.
+// The two 64-bit halves are encoded in big-endian order, first half first.
+func newMetroHash128Hasher() Hasher {
+	return &hasher{
+		name: "metrohash128",
+		HashFunc: newOneShotHash(16, func(b []byte) []byte {
+			h1, h2 := metro.Hash128(b, 0)
+			return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, h1), h2)
+		}),
+	}
+}
@@ -0,0 +1,120 @@
+package hasher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// MultiHash generates the hashes of several algorithms over the same input, reading an io.Reader only once.
+type MultiHash struct {
+	hashers []Hasher
+}
+
+// NewMultiHash returns a new MultiHash. Each option selects one algorithm, e.g.
+// NewMultiHash(WithMd5(), WithSha1(), WithSha256()).
+func NewMultiHash(opts ...Option) *MultiHash {
+	m := &MultiHash{}
+	for _, opt := range opts {
+		m.hashers = append(m.hashers, NewHash(opt).hasher)
+	}
+	return m
+}
+
+// GenerateAll generates the hash of the input with every algorithm, and returns the hashes keyed by
+// algorithm name (e.g. "sha256"; see AvailableAlgorithms). If an algorithm is selected twice, its hash
+// is returned once.
+// The input can be a string, a []byte, or an io.Reader. If every algorithm is backed by a hash.Hash, an io.Reader is
+// read once and fed to all of them through io.MultiWriter. Otherwise, e.g. with perceptual hash, the content
+// is buffered in memory and hashed by each algorithm in turn.
+// If some algorithms fail, the map holds the hashes of the others, and the returned error joins the
+// failures, each prefixed with the algorithm name. If reading the input fails, only the error is returned.
+func (m *MultiHash) GenerateAll(input any) (map[string][]byte, error) {
+	var (
+		digests [][]byte
+		errs    []error
+	)
+	switch v := normalizeInput(input).(type) {
+	case string:
+		digests, errs = make([][]byte, len(m.hashers)), make([]error, len(m.hashers))
+		for i, h := range m.hashers {
+			digests[i], errs[i] = h.GenHashFromString(v)
+		}
+	case io.Reader:
+		var err error
+		if digests, errs, err = hashEach(m.hashers, v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedInputType, v)
+	}
+
+	result := make(map[string][]byte, len(m.hashers))
+	var failures []error
+	for i, h := range m.hashers {
+		name := algorithmName(h)
+		if errs[i] != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", name, errs[i]))
+			continue
+		}
+		result[name] = digests[i]
+	}
+	return result, errors.Join(failures...)
+}
+
+// hashEach generates the hash of r with each hasher. digests[i] and errs[i] are the result of hashers[i].
+// If every hasher is backed by a hash.Hash, r is read once and fanned out to all of them.
+// Otherwise, the content is buffered in memory and hashed by each hasher in turn.
+// An error reading r is returned as err.
+func hashEach(hashers []Hasher, r io.Reader) (digests [][]byte, errs []error, err error) {
+	hashes := make([]hash.Hash, 0, len(hashers))
+	writers := make([]io.Writer, 0, len(hashers))
+	for _, h := range hashers {
+		s, ok := h.(streamer)
+		if !ok {
+			return hashEachBuffered(hashers, r)
+		}
+		hs := s.newHash()
+		hashes = append(hashes, hs)
+		writers = append(writers, hs)
+	}
+
+	if _, err := copyBuffer(io.MultiWriter(writers...), r, 0); err != nil {
+		return nil, nil, err
+	}
+
+	digests = make([][]byte, len(hashes))
+	for i, hs := range hashes {
+		digests[i] = hs.Sum(nil)
+	}
+	return digests, make([]error, len(hashers)), nil
+}
+
+// hashEachBuffered reads all of r into memory and generates the hash of the content with each hasher.
+func hashEachBuffered(hashers []Hasher, r io.Reader) (digests [][]byte, errs []error, err error) {
+	b, err := readAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digests, errs = make([][]byte, len(hashers)), make([]error, len(hashers))
+	for i, h := range hashers {
+		if bh, ok := h.(BytesHasher); ok {
+			digests[i], errs[i] = bh.GenHashFromBytes(b)
+			continue
+		}
+		digests[i], errs[i] = h.GenHashFromIOReader(bytes.NewReader(b))
+	}
+	return digests, errs, nil
+}
+
+// readAll reads r until io.EOF and returns the content. The unread content of a *bytes.Buffer is
+// taken without copying it; other readers are read with io.ReadAll.
+func readAll(r io.Reader) ([]byte, error) {
+	if buf, ok := r.(*bytes.Buffer); ok {
+		return buf.Next(buf.Len()), nil
+	}
+	return io.ReadAll(r)
+}
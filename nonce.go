@@ -0,0 +1,38 @@
+package hasher
+
+import "crypto/rand"
+
+// NonceSize is the length in bytes of the nonce generated by GenerateWithNonce.
+const NonceSize = 16
+
+// GenerateWithNonce generates a hash of nonce || input, where nonce is NonceSize random bytes
+// read from crypto/rand. The nonce is different for every call, so the same input yields a
+// different digest each time. Keep the returned nonce to verify the digest with CompareWithNonce.
+// The input can be a string, a []byte, an io.Reader, or a ReaderFunc, as for Generate; a seekable
+// io.Reader or a ReaderFunc can be retried with WithRetry.
+func (h *Hash) GenerateWithNonce(input any) (digest []byte, nonce []byte, err error) {
+	nonce = make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+
+	in, err := prefixInput(nonce, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	digest, err = h.Generate(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	return digest, nonce, nil
+}
+
+// CompareWithNonce compares a digest generated by GenerateWithNonce with nonce || input.
+// If the digest matches, nil is returned. Otherwise, ErrHashMismatch is returned.
+func (h *Hash) CompareWithNonce(digest, nonce []byte, input any) error {
+	in, err := prefixInput(nonce, input)
+	if err != nil {
+		return err
+	}
+	return h.Compare(digest, in)
+}
@@ -0,0 +1,36 @@
+package hasher
+
+import (
+	"io"
+	"time"
+)
+
+// observe generates a hash from the input, hashing io.Reader input with fn, and reports the algorithm, the number of bytes hashed,
+// and the elapsed time to the observer configured by WithObserver.
+// If hashing an io.Reader is retried, the bytes of the last attempt are reported.
+func (h *Hash) observe(input any, fn func(io.Reader) ([]byte, error)) ([]byte, error) {
+	var n int64
+	switch v := input.(type) {
+	case string:
+		n = int64(len(v))
+	case []byte:
+		n = int64(len(v))
+	}
+
+	start := time.Now()
+	digest, err := h.hashInput(input, func(r io.Reader) ([]byte, error) {
+		cr := &countingReader{r: r}
+		defer func() { n = cr.n }()
+		return fn(cr)
+	})
+	h.observer(algorithmName(h.hasher), n, time.Since(start))
+	return digest, err
+}
+
+// observeOperation reports an operation made of several hashes, such as TreeHash, to the observer configured
+// by WithObserver as a single observation of n bytes of input that started at start.
+func (h *Hash) observeOperation(start time.Time, n int64) {
+	if h.observer != nil {
+		h.observer(algorithmName(h.hasher), n, time.Since(start))
+	}
+}
@@ -0,0 +1,47 @@
+package hasher
+
+import (
+	"bytes"
+	"hash"
+)
+
+// oneShotDigest is a hash.Hash for algorithms that are only available as a function of the whole input,
+// such as FarmHash. It buffers everything written to it in memory and computes the hash in Sum.
+type oneShotDigest struct {
+	buf  bytes.Buffer
+	size int
+	// sum returns the hash of b.
+	sum func(b []byte) []byte
+}
+
+// newOneShotHash returns a function that creates oneShotDigests of size bytes computing their hash with sum.
+func newOneShotHash(size int, sum func(b []byte) []byte) func() hash.Hash {
+	return func() hash.Hash {
+		return &oneShotDigest{size: size, sum: sum}
+	}
+}
+
+// Write adds p to the buffered input. It never returns an error.
+func (d *oneShotDigest) Write(p []byte) (int, error) {
+	return d.buf.Write(p)
+}
+
+// Sum appends the hash of the buffered input to b.
+func (d *oneShotDigest) Sum(b []byte) []byte {
+	return append(b, d.sum(d.buf.Bytes())...)
+}
+
+// Reset discards the buffered input.
+func (d *oneShotDigest) Reset() {
+	d.buf.Reset()
+}
+
+// Size returns the length of the hash in bytes.
+func (d *oneShotDigest) Size() int {
+	return d.size
+}
+
+// BlockSize returns 1 because the input is not processed in blocks until Sum is called.
+func (d *oneShotDigest) BlockSize() int {
+	return 1
+}
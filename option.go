@@ -1,5 +1,18 @@
 package hasher
 
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
 // Option sets the options for the Hasher struct.
 type Option func(*Hash)
 
@@ -31,6 +44,20 @@ func WithSha256() Option {
 	}
 }
 
+// WithSha224 is an option that sets the hash algorithm to SHA-224.
+func WithSha224() Option {
+	return func(h *Hash) {
+		h.hasher = newSHA224Hasher()
+	}
+}
+
+// WithSha384 is an option that sets the hash algorithm to SHA-384.
+func WithSha384() Option {
+	return func(h *Hash) {
+		h.hasher = newSHA384Hasher()
+	}
+}
+
 // WithSha512 is an option that sets the hash algorithm to SHA-512.
 func WithSha512() Option {
 	return func(h *Hash) {
@@ -38,6 +65,83 @@ func WithSha512() Option {
 	}
 }
 
+// WithSha3_256 is an option that sets the hash algorithm to SHA3-256.
+func WithSha3_256() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newSHA3_256Hasher()
+	}
+}
+
+// WithSha3_384 is an option that sets the hash algorithm to SHA3-384.
+func WithSha3_384() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newSHA3_384Hasher()
+	}
+}
+
+// WithSha3_512 is an option that sets the hash algorithm to SHA3-512.
+func WithSha3_512() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newSHA3_512Hasher()
+	}
+}
+
+// WithKeccak256 is an option that sets the hash algorithm to the original Keccak-256, as used by Ethereum.
+// It differs from SHA3-256 (WithSha3_256) in its padding, so the hashes are different.
+func WithKeccak256() Option {
+	return func(h *Hash) {
+		h.hasher = newKeccak256Hasher()
+	}
+}
+
+// WithShake128 is an option that sets the hash algorithm to the SHAKE128 extendable-output function.
+// The hash length is outLen bytes. If outLen is not positive, Generate and Compare return ErrInvalidOutputLength.
+func WithShake128(outLen int) Option {
+	return func(h *Hash) {
+		h.hasher = newShake128Hasher(outLen)
+	}
+}
+
+// WithShake256 is an option that sets the hash algorithm to the SHAKE256 extendable-output function.
+// The hash length is outLen bytes. If outLen is not positive, Generate and Compare return ErrInvalidOutputLength.
+func WithShake256(outLen int) Option {
+	return func(h *Hash) {
+		h.hasher = newShake256Hasher(outLen)
+	}
+}
+
+// WithHMAC is an option that sets the hash algorithm to HMAC with the given key and hash function,
+// e.g. NewHash(WithHMAC(key, sha256.New)). The key is copied. Compare checks HMACs in constant time.
+func WithHMAC(key []byte, fn func() hash.Hash) Option {
+	return func(h *Hash) {
+		h.hasher = newHMACHasher("hmac", key, fn)
+	}
+}
+
+// WithHMACSha256 is an option that sets the hash algorithm to HMAC-SHA256 with the given key.
+// The key is copied. Compare checks HMACs in constant time.
+func WithHMACSha256(key []byte) Option {
+	return func(h *Hash) {
+		h.hasher = newHMACHasher("hmac-sha256", key, sha256.New)
+	}
+}
+
+// WithHMACSha512 is an option that sets the hash algorithm to HMAC-SHA512 with the given key.
+// The key is copied. Compare checks HMACs in constant time.
+func WithHMACSha512(key []byte) Option {
+	return func(h *Hash) {
+		h.hasher = newHMACHasher("hmac-sha512", key, sha512.New)
+	}
+}
+
+// WithRipemd160 is an option that sets the hash algorithm to RIPEMD-160.
+// RIPEMD-160 is a legacy algorithm, e.g. used to derive Bitcoin addresses. Use it only for interoperability.
+func WithRipemd160() Option {
+	return func(h *Hash) {
+		h.hasher = newRipemd160Hasher()
+	}
+}
+
 // WithPhash is an option that sets the hash algorithm to Perceptual Hash.
 func WithPhash() Option {
 	return func(h *Hash) {
@@ -45,6 +149,24 @@ func WithPhash() Option {
 	}
 }
 
+// WithAverageHash is an option that sets the hash algorithm to average hash (aHash), a perceptual hash
+// that is faster but less robust than WithPhash. Like WithPhash, it does not support string input.
+// The hash length is 8 bytes.
+func WithAverageHash() Option {
+	return func(h *Hash) {
+		h.hasher = newAverageHasher()
+	}
+}
+
+// WithDifferenceHash is an option that sets the hash algorithm to difference hash (dHash), a perceptual hash
+// based on the brightness gradient, which is as fast as average hash and more robust against changes of
+// brightness and contrast. Like WithPhash, it does not support string input. The hash length is 8 bytes.
+func WithDifferenceHash() Option {
+	return func(h *Hash) {
+		h.hasher = newDifferenceHasher()
+	}
+}
+
 // WithFnv32 is an option that sets the hash algorithm to FNV-32.
 func WithFnv32() Option {
 	return func(h *Hash) {
@@ -94,6 +216,67 @@ func WithBlake3() Option {
 	}
 }
 
+// WithBlake3Keyed is an option that sets the hash algorithm to BLAKE3 in the keyed hash mode,
+// which turns the hash into a MAC without the overhead of HMAC. The hash length is 64 bytes.
+func WithBlake3Keyed(key [32]byte) Option {
+	return func(h *Hash) {
+		h.hasher = &blake3Hasher{key: key[:]}
+	}
+}
+
+// WithBlake3DeriveKey is an option that sets the hash algorithm to BLAKE3 in the key derivation mode.
+// The input is the key material, and the hash is the 64-byte key derived from it for context.
+// The context should be hardcoded, globally unique, and application-specific,
+// e.g. "example.com 2019-12-25 16:18:03 session tokens v1".
+// The key derivation mode cannot hash incrementally, so an io.Reader is read into memory.
+func WithBlake3DeriveKey(context string) Option {
+	return func(h *Hash) {
+		h.hasher = &blake3DeriveKeyHasher{context: context}
+	}
+}
+
+// WithBlake2b_256 is an option that sets the hash algorithm to BLAKE2b-256.
+// The hash length is 32 bytes.
+func WithBlake2b_256() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newBlake2b256Hasher()
+	}
+}
+
+// WithBlake2b_512 is an option that sets the hash algorithm to BLAKE2b-512.
+// The hash length is 64 bytes.
+func WithBlake2b_512() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newBlake2b512Hasher()
+	}
+}
+
+// WithBlake2bKeyed is an option that sets the hash algorithm to BLAKE2b-512 keyed with key,
+// which turns the hash into a MAC without the overhead of HMAC. The hash length is 64 bytes.
+// The key must be at most 64 bytes; otherwise Generate and Compare return ErrInvalidKeyLength.
+// An empty key is the same as the unkeyed BLAKE2b-512.
+func WithBlake2bKeyed(key []byte) Option {
+	return func(h *Hash) {
+		h.hasher = newBlake2b512KeyedHasher(key)
+	}
+}
+
+// WithHighwayHash is an option that sets the hash algorithm to HighwayHash-256 keyed with key.
+// HighwayHash is a fast keyed hash for high throughput checksums. The hash length is 32 bytes.
+func WithHighwayHash(key [32]byte) Option {
+	return func(h *Hash) {
+		h.hasher = newHighwayHasher(key)
+	}
+}
+
+// WithBlake2s_256 is an option that sets the hash algorithm to BLAKE2s-256.
+// The hash length is 32 bytes.
+func WithBlake2s_256() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newBlake2s256Hasher()
+	}
+}
+
 // WithAdler32 is an option that sets the hash algorithm to Adler-32.
 func WithAdler32() Option {
 	return func(h *Hash) {
@@ -115,16 +298,414 @@ func WithWhirlpool() Option {
 	}
 }
 
-// WithCRC32 is an option that sets the hash algorithm to CRC-32.
+// WithCRC32 is an option that sets the hash algorithm to CRC-32 with the IEEE polynomial.
+// The IEEE polynomial is kept for compatibility; use WithCRC32Castagnoli or WithCRC32Koopman for other polynomials.
 func WithCRC32() Option {
 	return func(h *Hash) {
 		h.hasher = newCRC32Hasher()
 	}
 }
 
+// WithCRC32Castagnoli is an option that sets the hash algorithm to CRC32 with the Castagnoli polynomial (CRC32C),
+// which is used by storage systems such as iSCSI and ext4, and is hardware-accelerated on most CPUs.
+func WithCRC32Castagnoli() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC32CastagnoliHasher()
+	}
+}
+
+// WithCRC32Koopman is an option that sets the hash algorithm to CRC32 with the Koopman polynomial.
+func WithCRC32Koopman() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC32KoopmanHasher()
+	}
+}
+
+// WithCRC64ISO is an option that sets the hash algorithm to CRC-64 with the ISO polynomial.
+// The hash length is 8 bytes.
+func WithCRC64ISO() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC64ISOHasher()
+	}
+}
+
+// WithCRC64ECMA is an option that sets the hash algorithm to CRC-64 with the ECMA polynomial,
+// which is the CRC-64 used by the xz format. The hash length is 8 bytes.
+func WithCRC64ECMA() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC64ECMAHasher()
+	}
+}
+
+// WithCRC8 is an option that sets the hash algorithm to CRC-8 with the given polynomial.
+// The checksum is computed most significant bit first, with an initial value and a final XOR value of zero.
+// For the SMBus and Maxim variants, use WithCRC8SMBus and WithCRC8Maxim.
+func WithCRC8(poly byte) Option {
+	return func(h *Hash) {
+		h.hasher = newCRC8Hasher(poly)
+	}
+}
+
+// WithCRC8SMBus is an option that sets the hash algorithm to CRC-8/SMBUS (polynomial 0x07).
+func WithCRC8SMBus() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC8SMBusHasher()
+	}
+}
+
+// WithCRC8Maxim is an option that sets the hash algorithm to CRC-8/MAXIM (polynomial 0x31, reflected),
+// used by Maxim/Dallas 1-Wire devices.
+func WithCRC8Maxim() Option {
+	return func(h *Hash) {
+		h.hasher = newCRC8MaximHasher()
+	}
+}
+
 // WithXXHash is an option that sets the hash algorithm to XXHash.
 func WithXXHash() Option {
 	return func(h *Hash) {
 		h.hasher = newXXHasher()
 	}
 }
+
+// WithXXH3_64 is an option that sets the hash algorithm to XXH3-64, a faster successor of XXHash.
+// The hash length is 8 bytes.
+func WithXXH3_64() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newXXH3_64Hasher()
+	}
+}
+
+// WithXXH3_128 is an option that sets the hash algorithm to XXH3-128. The hash length is 16 bytes.
+func WithXXH3_128() Option { //nolint:revive,stylecheck
+	return func(h *Hash) {
+		h.hasher = newXXH3_128Hasher()
+	}
+}
+
+// WithFarmHash64 is an option that sets the hash algorithm to FarmHash (Fingerprint64), a fast hash for strings
+// in data structures such as hash tables. FarmHash is not a cryptographic hash, so do not use it where an
+// attacker can choose the input. The input is hashed in memory. The hash length is 8 bytes.
+func WithFarmHash64() Option {
+	return func(h *Hash) {
+		h.hasher = newFarmHash64Hasher()
+	}
+}
+
+// WithMetroHash64 is an option that sets the hash algorithm to MetroHash64, a fast non-cryptographic hash.
+// The input is hashed in memory. The hash length is 8 bytes.
+func WithMetroHash64() Option {
+	return func(h *Hash) {
+		h.hasher = newMetroHash64Hasher()
+	}
+}
+
+// WithMetroHash128 is an option that sets the hash algorithm to MetroHash128, a fast non-cryptographic hash.
+// The input is hashed in memory. The hash length is 16 bytes.
+func WithMetroHash128() Option {
+	return func(h *Hash) {
+		h.hasher = newMetroHash128Hasher()
+	}
+}
+
+// WithBcrypt is an option that sets the hash algorithm to bcrypt with the given cost.
+// bcrypt is for storing passwords, not for file integrity: only string input is supported, and
+// io.Reader input returns ErrStreamingNotSupported. Each generated hash embeds a random salt,
+// so use Compare to verify a password against a hash.
+// If cost is less than bcrypt.MinCost (4), bcrypt.DefaultCost (10) is used.
+func WithBcrypt(cost int) Option {
+	return func(h *Hash) {
+		h.hasher = &bcryptHasher{cost: cost}
+	}
+}
+
+// WithArgon2id is an option that sets the hash algorithm to Argon2id with the given salt and parameters:
+// the number of passes (time), the memory in KiB, the degree of parallelism (threads), and the length
+// of the hash in bytes (keyLen). Argon2id is for storing passwords, not for file integrity: only string
+// input is supported, and io.Reader input returns ErrStreamingNotSupported.
+// The salt should be random, at least 16 bytes, unique per password, and stored with the hash.
+// Zero parameters are replaced by the recommendation of RFC 9106 (time 3, memory 64 MiB, threads 4, keyLen 32).
+// e.g. NewHash(WithArgon2id(salt, 3, 64*1024, 4, 32))
+func WithArgon2id(salt []byte, time, memory uint32, threads uint8, keyLen uint32) Option {
+	return func(h *Hash) {
+		h.hasher = newArgon2Hasher(salt, time, memory, threads, keyLen)
+	}
+}
+
+// WithBufferSize is an option that sets the size of the buffer used to copy an io.Reader into the hash.
+// Copy buffers are pooled and shared between calls, so hashing many readers does not allocate a new
+// buffer each time. The option applies to the built-in algorithms backed by a hash.Hash.
+// Non-positive values are ignored and the default size (32KB) is used.
+func WithBufferSize(n int) Option {
+	return func(h *Hash) {
+		if n > 0 {
+			h.bufferSize = n
+		}
+	}
+}
+
+// WithCompactSizePrefix is an option that prefixes the input with its length encoded as a
+// Bitcoin CompactSize (varint) before hashing, as used for Bitcoin message hashing.
+// The length is encoded as follows:
+//   - less than 0xfd (253): a single byte.
+//   - up to 0xffff: 0xfd followed by the length as a little-endian uint16.
+//   - up to 0xffffffff: 0xfe followed by the length as a little-endian uint32.
+//   - otherwise: 0xff followed by the length as a little-endian uint64.
+//
+// The length of an io.Reader is not known in advance, so the reader is read into memory first.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithCompactSizePrefix())
+func WithCompactSizePrefix() Option {
+	return func(h *Hash) {
+		h.hasher = newLengthPrefixHasher(h.hasher, compactSize)
+	}
+}
+
+// WithLengthPrefix is an option that prefixes the input with its length as an 8-byte big-endian integer
+// before hashing, i.e. the hashed data is length || input. The whole input is framed once: an input built by
+// concatenating fields is still ambiguous, e.g. "ab"+"c" and "a"+"bc" hash alike. It is meant for domain
+// separation, so it changes the digest: hashes generated with and without it do not match.
+// The length of an io.Reader is not known in advance, so the reader is read into memory first.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithLengthPrefix())
+func WithLengthPrefix() Option {
+	return func(h *Hash) {
+		h.hasher = newLengthPrefixHasher(h.hasher, uint64BigEndian)
+	}
+}
+
+// WithSalt is an option that adds salt to the input before hashing, before it (SaltPrefix) or after it (SaltSuffix),
+// so the same input hashes differently with different salts. The salt is copied.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithSalt(salt, SaltPrefix))
+func WithSalt(salt []byte, mode SaltMode) Option {
+	return func(h *Hash) {
+		h.hasher = newSaltHasher(h.hasher, salt, mode)
+	}
+}
+
+// WithDomainSeparation is an option that prefixes the input with a fixed context string, so the same input
+// hashes differently in different protocols or purposes, e.g. NewHash(WithSha256(), WithDomainSeparation("myapp v1 session")).
+// The prefix is the length of the context as an 8-byte big-endian integer followed by the context, so one
+// context cannot be a prefix of another.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option; placed
+// before it, it is discarded when the algorithm is set. Placed after input decorators such as WithSalt,
+// the context is written before their output.
+func WithDomainSeparation(ctx string) Option {
+	prefix := append(uint64BigEndian(uint64(len(ctx))), ctx...)
+	return func(h *Hash) {
+		h.hasher = newPrefixHasher(h.hasher, func() []byte { return prefix })
+	}
+}
+
+// WithHexDecodeReader is an option that hex-decodes io.Reader input before hashing, so the digest
+// of a hex dump stored in a text file is the digest of the binary it represents.
+// ASCII whitespace in the reader, such as line breaks, is ignored. Malformed hex is returned as
+// a wrapped error from Generate and Compare. String input is hashed as-is.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithHexDecodeReader())
+func WithHexDecodeReader() Option {
+	return func(h *Hash) {
+		h.hasher = &inputHasher{
+			base:       h.hasher,
+			fromReader: hexDecodeReader,
+		}
+	}
+}
+
+// WithTimeBucket is an option that prefixes the input with the current time truncated to d,
+// so the digest of the same input changes once per time bucket (e.g. every hour). It is useful
+// for rotating cache keys that expire on their own.
+// The prefix is the truncated Unix time in seconds, encoded as a big-endian int64.
+// clock returns the current time; if it is nil, time.Now is used.
+// If d is not positive, Generate and Compare return ErrInvalidTimeBucket.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithTimeBucket(time.Hour, nil))
+func WithTimeBucket(d time.Duration, clock func() time.Time) Option {
+	if clock == nil {
+		clock = time.Now
+	}
+	return func(h *Hash) {
+		if d <= 0 {
+			h.hasher = &failingHasher{name: algorithmName(h.hasher), err: fmt.Errorf("%w: %v", ErrInvalidTimeBucket, d)}
+			return
+		}
+		h.hasher = newPrefixHasher(h.hasher, func() []byte {
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, uint64(clock().Truncate(d).Unix()))
+			return b
+		})
+	}
+}
+
+// WithConcatenate is an option that sets the hash algorithm to a composite of several algorithms.
+// Each option selects one algorithm; the digest is the concatenation of each algorithm's digest
+// over the same input, in the order given.
+// e.g. NewHash(WithConcatenate(WithMd5(), WithSha1())) generates md5(input) || sha1(input).
+func WithConcatenate(opts ...Option) Option {
+	return func(h *Hash) {
+		h.hasher = newConcatHasher(opts...)
+	}
+}
+
+// WithProgressBar is an option that renders a textual progress bar to w while an io.Reader is hashed.
+// total is the expected size of the input in bytes. If total is not positive, the number of bytes
+// read so far is rendered instead of a bar. A final line (100% when total is known) is written
+// once the reader is exhausted. String input does not render progress.
+func WithProgressBar(w io.Writer, total int64) Option {
+	return func(h *Hash) {
+		h.progressBar = &progressBar{w: w, total: total}
+	}
+}
+
+// WithConcurrency is an option that sets the number of goroutines GenerateBatch hashes the inputs with,
+// and TreeHashAt hashes the blocks with.
+// The algorithm is shared by the goroutines, so a user-defined Hasher must be safe for concurrent use.
+// Non-positive values are ignored: GenerateBatch then hashes the inputs one after another,
+// and TreeHashAt uses runtime.GOMAXPROCS(0) goroutines.
+func WithConcurrency(n int) Option {
+	return func(h *Hash) {
+		if n > 0 {
+			h.concurrency = n
+		}
+	}
+}
+
+// WithTimeout is an option that limits how long Generate may take to hash an io.Reader.
+// It is the same as calling GenerateContext with a context that times out after d: if hashing takes
+// longer, context.DeadlineExceeded is returned. String input is hashed at once and is not limited.
+// The timeout applies to Generate and the methods built on it, such as GenerateHex, but not to Compare.
+// Non-positive values are ignored.
+func WithTimeout(d time.Duration) Option {
+	return func(h *Hash) {
+		if d > 0 {
+			h.timeout = d
+		}
+	}
+}
+
+// WithRetry is an option that retries hashing an io.Reader up to retries times when reading it fails,
+// e.g. because of a transient network error. Before each retry, the input is rewound to where it
+// started if it is an io.Seeker, or reopened if it is a ReaderFunc. Other readers cannot be
+// re-read, so they fail immediately. Errors that are not read errors, such as ErrHashMismatch,
+// are never retried.
+func WithRetry(retries int) Option {
+	return func(h *Hash) {
+		h.retries = retries
+	}
+}
+
+// WithTrimSpace is an option that removes leading and trailing white space from the input before hashing,
+// so that text differing only in surrounding white space (e.g. pasted by a user) hashes identically.
+// White space is defined by Unicode, as in strings.TrimSpace. An io.Reader is read into memory to be trimmed.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithTrimSpace())
+func WithTrimSpace() Option {
+	return func(h *Hash) {
+		h.hasher = &inputHasher{
+			base: h.hasher,
+			fromString: func(s string) (string, error) {
+				return strings.TrimSpace(s), nil
+			},
+			fromReader: func(r io.Reader) (io.Reader, error) {
+				b, err := io.ReadAll(r)
+				if err != nil {
+					return nil, err
+				}
+				return bytes.NewReader(bytes.TrimSpace(b)), nil
+			},
+		}
+	}
+}
+
+// WithUTF16LE is an option that encodes string input as UTF-16LE (without a byte order mark) before hashing,
+// for interoperability with Windows tools that hash UTF-16 strings. io.Reader input is hashed as-is,
+// because it is assumed to be already encoded.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithUTF16LE())
+func WithUTF16LE() Option {
+	return func(h *Hash) {
+		h.hasher = &inputHasher{
+			base: h.hasher,
+			fromString: func(s string) (string, error) {
+				u := utf16.Encode([]rune(s))
+				b := make([]byte, 2*len(u))
+				for i, v := range u {
+					binary.LittleEndian.PutUint16(b[2*i:], v)
+				}
+				return string(b), nil
+			},
+		}
+	}
+}
+
+// WithCheckpoint is an option that saves the hash state to the file at path every time at least
+// every bytes of an io.Reader have been hashed. If hashing is interrupted, e.g. by a crash or a
+// broken network stream, ResumeFrom continues from the last checkpoint instead of starting over.
+// The checkpoint file is removed once the reader is fully hashed.
+// Only algorithms whose hash state can be marshaled (such as MD5 and the SHA family) support
+// checkpoints; others return ErrStateNotSupported. If every is not positive, the option is ignored.
+// Operations made of several hashes, such as TreeHash, GenerateTarContent, and GenerateBatch,
+// do not save checkpoints.
+func WithCheckpoint(path string, every int64) Option {
+	return func(h *Hash) {
+		if every > 0 {
+			h.checkpoint = &checkpoint{path: path, every: every}
+		}
+	}
+}
+
+// WithPhashMaxDimension is an option that limits the width and height of the images hashed by Perceptual Hash
+// to px, which bounds the memory used to decode them. The size of an image is read from its header before it is
+// decoded, and an image whose width or height exceeds px is rejected with ErrImageTooLarge without being decoded.
+// If px is not positive, images of any size are hashed.
+// This option configures the Perceptual Hash set by WithPhash, so it must be placed right after WithPhash,
+// e.g. NewHash(WithPhash(), WithPhashMaxDimension(4096)). With any other algorithm, Generate and Compare
+// return ErrInvalidOption.
+func WithPhashMaxDimension(px int) Option {
+	return func(h *Hash) {
+		if _, ok := h.hasher.(*pHasher); !ok {
+			h.hasher = &failingHasher{
+				name: algorithmName(h.hasher),
+				err:  fmt.Errorf("%w: WithPhashMaxDimension requires WithPhash", ErrInvalidOption),
+			}
+			return
+		}
+		if px < 0 {
+			px = 0
+		}
+		h.hasher = &pHasher{maxDimension: px}
+	}
+}
+
+// WithObserver is an option that calls fn after every Generate with the algorithm name, the number of
+// bytes hashed, and the time Generate took, e.g. to export hashing metrics to a monitoring system.
+// fn is also called when Generate fails, with the bytes read so far. The observer does not change
+// the values returned by Generate. Operations made of several hashes, such as TreeHash, GenerateTarContent,
+// and GenerateBatch, call fn once with the bytes of their whole input, and Chunker.Next calls it once per chunk.
+// If fn is nil, the option is ignored.
+// e.g. NewHash(WithSha256(), WithObserver(func(algorithm string, bytes int64, d time.Duration) { ... }))
+func WithObserver(fn func(algorithm string, bytes int64, d time.Duration)) Option {
+	return func(h *Hash) {
+		h.observer = fn
+	}
+}
+
+// WithEncoding is an option that sets the text encoding of the hashes returned by GenerateString.
+// The default is EncodingHex. It does not change the bytes returned by Generate.
+func WithEncoding(enc Encoding) Option {
+	return func(h *Hash) {
+		h.encoding = enc
+	}
+}
+
+// WithTruncate is an option that truncates the hashes to their first n bytes, e.g. a 64-bit short SHA-256.
+// Compare compares against n bytes. If n is not positive or longer than the hashes of the algorithm,
+// Generate and Compare return ErrInvalidTruncation.
+// This option wraps the configured algorithm, so it must be placed after the algorithm option.
+// e.g. NewHash(WithSha256(), WithTruncate(8))
+func WithTruncate(n int) Option {
+	return func(h *Hash) {
+		h.hasher = newTruncateHasher(h.hasher, n)
+	}
+}
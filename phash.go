@@ -2,14 +2,34 @@ package hasher
 
 import (
 	"bytes"
+	"crypto/subtle"
 	"encoding/binary"
+	"fmt"
 	"image"
 	"io"
+	"math/bits"
 
 	"github.com/azr/phash"
 )
 
-type pHasher struct{}
+// phashSize is the length of a perceptual hash in bytes.
+const phashSize = 8
+
+type pHasher struct {
+	// maxDimension is the maximum width and height of the image that is hashed. Larger images are rejected
+	// with ErrImageTooLarge before they are decoded. If it is zero, images of any size are hashed.
+	maxDimension int
+}
+
+// Name returns the name of the algorithm.
+func (p *pHasher) Name() string {
+	return "phash"
+}
+
+// size returns the length of a perceptual hash.
+func (p *pHasher) size() int {
+	return phashSize
+}
 
 // GenHashFromString always returns ErrPhashNotSupportedString because perceptual hashing  does not support string input.
 func (p *pHasher) GenHashFromString(_ string) ([]byte, error) {
@@ -22,16 +42,50 @@ func (p *pHasher) CmpHashAndString(_ []byte, _ string) error {
 }
 
 // GenHashFromIOReader generates a hash from an io.Reader using the perceptual hashing  algorithm.
+// If maxDimension is set, the size of the image is read from its header first, and the image
+// is not decoded if it is too large.
 func (p *pHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	if p.maxDimension > 0 {
+		// DecodeConfig consumes the header, so it is read again from header before the rest of r.
+		var header bytes.Buffer
+		cfg, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+		}
+		if err := p.checkDimension(cfg.Width, cfg.Height); err != nil {
+			return nil, err
+		}
+		r = io.MultiReader(&header, r)
+	}
+
 	img, _, err := image.Decode(r)
 	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImage, err)
+	}
+	return p.genHashFromImage(img)
+}
+
+// genHashFromImage generates a hash from a decoded image using the perceptual hashing algorithm.
+func (p *pHasher) genHashFromImage(img image.Image) ([]byte, error) {
+	if img == nil || img.Bounds().Empty() {
+		return nil, fmt.Errorf("%w: decoded image is empty", ErrInvalidImage)
+	}
+	if err := p.checkDimension(img.Bounds().Dx(), img.Bounds().Dy()); err != nil {
 		return nil, err
 	}
-	hashBytes := make([]byte, 8)
+	hashBytes := make([]byte, phashSize)
 	binary.LittleEndian.PutUint64(hashBytes, phash.DTC(img))
 	return hashBytes, nil
 }
 
+// checkDimension returns ErrImageTooLarge if the width or height exceeds maxDimension.
+func (p *pHasher) checkDimension(width, height int) error {
+	if p.maxDimension > 0 && (width > p.maxDimension || height > p.maxDimension) {
+		return fmt.Errorf("%w: %dx%d, want at most %dx%d", ErrImageTooLarge, width, height, p.maxDimension, p.maxDimension)
+	}
+	return nil
+}
+
 // CmpHashAndIOReader compares a hash and an io.Reader using the md5sum algorithm.
 func (p *pHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 	hashB, err := p.GenHashFromIOReader(r)
@@ -39,8 +93,67 @@ func (p *pHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
 		return err
 	}
 
-	if !bytes.Equal(hashA, hashB) {
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
 		return ErrHashMismatch
 	}
 	return nil
 }
+
+// HammingDistance returns the number of bits that differ between a and b.
+// If the lengths differ, every bit of the extra bytes is counted as different.
+// It is the distance between two perceptual hashes: the smaller it is, the more similar the images are.
+func HammingDistance(a, b []byte) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	distance := 8 * (len(b) - len(a))
+	for i := range a {
+		distance += bits.OnesCount8(a[i] ^ b[i])
+	}
+	return distance
+}
+
+// CompareWithThreshold compares a hash and the hash of the input, allowing up to maxDistance different bits
+// (see HammingDistance). It is for perceptual hashes (phash, ahash, and dhash), where visually similar images
+// yield similar but not identical hashes; with a maxDistance of 0 it is the same as Compare. The bits of other
+// algorithms do not measure similarity, so they are rejected with ErrNotPerceptual. If more than maxDistance
+// bits differ, ErrHashMismatch is returned. As with Compare, errors are prefixed with the name of the algorithm.
+func (h *Hash) CompareWithThreshold(hash []byte, input any, maxDistance int) error {
+	if !isPerceptual(h.hasher) {
+		return h.wrapError(ErrNotPerceptual)
+	}
+	if err := h.checkHashLength(hash); err != nil {
+		return err
+	}
+
+	computed, err := h.Generate(input)
+	if err != nil {
+		return err
+	}
+	if distance := HammingDistance(hash, computed); distance > maxDistance {
+		return h.wrapError(fmt.Errorf("%w: %d bits differ, want at most %d", ErrHashMismatch, distance, maxDistance))
+	}
+	return nil
+}
+
+// isPerceptual reports whether hs, or the hasher wrapped by it, is a perceptual hash that can hash images.
+// Concatenated hashes are perceptual if all of their algorithms are.
+func isPerceptual(hs Hasher) bool {
+	for {
+		w, ok := hs.(wrapper)
+		if !ok {
+			break
+		}
+		hs = w.unwrap()
+	}
+	if c, ok := hs.(*concatHasher); ok {
+		for _, h := range c.hashers {
+			if !isPerceptual(h) {
+				return false
+			}
+		}
+		return len(c.hashers) > 0
+	}
+	_, ok := hs.(imageHasher)
+	return ok
+}
@@ -0,0 +1,175 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// progressBarWidth is the number of cells in the progress bar.
+const progressBarWidth = 40
+
+// progressBarUnknownStep is the number of bytes between progress updates when the total size is unknown.
+const progressBarUnknownStep = 1024 * 1024
+
+// progressBar is the configuration of a textual progress bar.
+type progressBar struct {
+	w     io.Writer
+	total int64
+}
+
+// countingReader is an io.Reader that counts the bytes read from the underlying reader.
+// onRead is called with the running total after every read that returns data, and
+// onEOF is called with the final total once the underlying reader returns io.EOF.
+type countingReader struct {
+	r      io.Reader
+	n      int64
+	onRead func(n int64)
+	onEOF  func(n int64)
+}
+
+// Read reads from the underlying reader and updates the byte count.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.n += int64(n)
+		if c.onRead != nil {
+			c.onRead(c.n)
+		}
+	}
+	if errors.Is(err, io.EOF) && c.onEOF != nil {
+		c.onEOF(c.n)
+		c.onEOF = nil
+	}
+	return n, err
+}
+
+// countingReadSeeker is a countingReader over an io.Seeker. Seeking moves the byte count with the
+// read position, so after WithRetry rewinds the reader, the count starts again from where it started.
+type countingReadSeeker struct {
+	*countingReader
+	s io.Seeker
+}
+
+// Seek seeks the underlying reader and adjusts the byte count by the distance moved.
+func (c *countingReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	cur, err := c.s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, err
+	}
+	pos, err := c.s.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+	c.n += pos - cur
+	return pos, nil
+}
+
+// newCountingReader returns c, keeping the io.Seeker and io.Closer methods of the underlying reader
+// available, so that WithRetry can still rewind a seekable reader and a reader opened by a ReaderFunc
+// is still closed.
+func newCountingReader(c *countingReader) io.Reader {
+	s, seekable := c.r.(io.Seeker)
+	cl, closable := c.r.(io.Closer)
+	switch {
+	case seekable && closable:
+		return struct {
+			*countingReadSeeker
+			io.Closer
+		}{&countingReadSeeker{countingReader: c, s: s}, cl}
+	case seekable:
+		return &countingReadSeeker{countingReader: c, s: s}
+	case closable:
+		return struct {
+			*countingReader
+			io.Closer
+		}{c, cl}
+	default:
+		return c
+	}
+}
+
+// GenerateWithProgress generates a hash from the input like Generate, and calls cb with the number
+// of bytes read so far every time data is read from an io.Reader, a []byte, or a ReaderFunc.
+// If hashing is retried (see WithRetry), the count starts again from zero with the reopened
+// ReaderFunc, or from where the rewound io.Reader started.
+// For a string, cb is called once with its length after it has been hashed.
+// Unlike WithProgressBar, cb is called for every read, so it should return quickly.
+func (h *Hash) GenerateWithProgress(input any, cb func(bytesRead int64)) ([]byte, error) {
+	switch v := normalizeInput(input).(type) {
+	case string:
+		digest, err := h.Generate(v)
+		if err != nil {
+			return nil, err
+		}
+		cb(int64(len(v)))
+		return digest, nil
+	case io.Reader:
+		return h.Generate(newCountingReader(&countingReader{r: v, onRead: cb}))
+	case ReaderFunc:
+		return h.Generate(ReaderFunc(func() (io.Reader, error) {
+			r, err := v()
+			if err != nil || r == nil {
+				// readWithRetry reports a nil reader.
+				return nil, err
+			}
+			return newCountingReader(&countingReader{r: r, onRead: cb}), nil
+		}))
+	default:
+		return h.Generate(v)
+	}
+}
+
+// reader returns a reader that renders the progress of reading r.
+// While reading, the bar is redrawn whenever the progress advances by at least one percent
+// (or by progressBarUnknownStep bytes if the total is unknown). A final line is written at io.EOF.
+func (p *progressBar) reader(r io.Reader) io.Reader {
+	last := int64(-1)
+	return &countingReader{
+		r: r,
+		onRead: func(n int64) {
+			step := n / progressBarUnknownStep
+			if p.total > 0 {
+				step = percent(n, p.total)
+			}
+			if step != last {
+				last = step
+				p.render(n, false)
+			}
+		},
+		onEOF: func(n int64) {
+			p.render(n, true)
+		},
+	}
+}
+
+// render writes the progress bar for n bytes read. Write errors are ignored because
+// the progress bar must not affect hashing.
+func (p *progressBar) render(n int64, done bool) {
+	suffix := ""
+	if done {
+		suffix = "\n"
+	}
+
+	if p.total <= 0 {
+		fmt.Fprintf(p.w, "\r%d bytes%s", n, suffix) //nolint:errcheck
+		return
+	}
+
+	pct := percent(n, p.total)
+	if done {
+		pct = 100
+	}
+	filled := int(pct) * progressBarWidth / 100
+	fmt.Fprintf(p.w, "\r[%s%s] %3d%% (%d/%d bytes)%s", //nolint:errcheck
+		strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled), pct, n, p.total, suffix)
+}
+
+// percent returns n as a percentage of total, capped at 100.
+func percent(n, total int64) int64 {
+	if n >= total {
+		return 100
+	}
+	return n * 100 / total
+}
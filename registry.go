@@ -0,0 +1,122 @@
+package hasher
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// registry maps algorithm names to the factories of their hashers.
+// It is used by NewHashByName and can be extended with Register.
+var registry = struct {
+	sync.RWMutex
+	factories map[string]func() Hasher
+}{
+	factories: map[string]func() Hasher{
+		string(AlgoMD5):          func() Hasher { return &md5sumHasher{} },
+		string(AlgoSHA1):         newSHA1Hasher,
+		string(AlgoSHA256):       newSHA256Hasher,
+		string(AlgoSHA224):       newSHA224Hasher,
+		string(AlgoSHA384):       newSHA384Hasher,
+		string(AlgoSHA512):       newSHA512Hasher,
+		string(AlgoRipemd160):    newRipemd160Hasher,
+		string(AlgoPhash):        func() Hasher { return &pHasher{} },
+		string(AlgoAhash):        newAverageHasher,
+		string(AlgoDhash):        newDifferenceHasher,
+		string(AlgoFNV32):        newFnv32Hasher,
+		string(AlgoFNV32a):       newFnv32aHasher,
+		string(AlgoFNV64):        newFnv64Hasher,
+		string(AlgoFNV64a):       newFnv64aHasher,
+		string(AlgoFNV128):       newFnv128Hasher,
+		string(AlgoFNV128a):      newFnv128aHasher,
+		string(AlgoBlake3):       func() Hasher { return &blake3Hasher{} },
+		string(AlgoAdler32):      newAdler32Hasher,
+		string(AlgoMmh3):         newMmh3Hasher,
+		string(AlgoWhirlpool):    newWhirlpoolHasher,
+		string(AlgoSHA3_256):     newSHA3_256Hasher,
+		string(AlgoSHA3_384):     newSHA3_384Hasher,
+		string(AlgoSHA3_512):     newSHA3_512Hasher,
+		string(AlgoKeccak256):    newKeccak256Hasher,
+		string(AlgoBlake2b256):   newBlake2b256Hasher,
+		string(AlgoBlake2b512):   newBlake2b512Hasher,
+		string(AlgoBlake2s256):   newBlake2s256Hasher,
+		string(AlgoCRC32):        newCRC32Hasher,
+		string(AlgoCRC32C):       newCRC32CastagnoliHasher,
+		string(AlgoCRC32Koopman): newCRC32KoopmanHasher,
+		string(AlgoCRC64ISO):     newCRC64ISOHasher,
+		string(AlgoCRC64ECMA):    newCRC64ECMAHasher,
+		string(AlgoCRC8SMBus):    newCRC8SMBusHasher,
+		string(AlgoCRC8Maxim):    newCRC8MaximHasher,
+		string(AlgoXXHash):       newXXHasher,
+		string(AlgoXXH3_64):      newXXH3_64Hasher,
+		string(AlgoXXH3_128):     newXXH3_128Hasher,
+		string(AlgoFarmHash64):   newFarmHash64Hasher,
+		string(AlgoMetroHash64):  newMetroHash64Hasher,
+		string(AlgoMetroHash128): newMetroHash128Hasher,
+	},
+}
+
+// Register registers a hasher factory under name, so that NewHashByName(name) can create it.
+// It is safe to call Register from multiple goroutines. If name is already registered,
+// ErrAlgorithmAlreadyRegistered is returned; call Unregister first to replace it.
+func Register(name string, factory func() Hasher) error {
+	if name == "" || factory == nil {
+		return fmt.Errorf("%w: name and factory must not be empty", ErrInvalidAlgorithm)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.factories[name]; ok {
+		return fmt.Errorf("%w: %s", ErrAlgorithmAlreadyRegistered, name)
+	}
+	registry.factories[name] = factory
+	return nil
+}
+
+// Unregister removes the algorithm registered under name. It does nothing if name is not registered.
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	delete(registry.factories, name)
+}
+
+// NewHashByName returns a new Hash using the algorithm registered under name, e.g. "sha256".
+// The names of the built-in algorithms are also matched case-insensitively and by alias,
+// e.g. "SHA-256" (see Algorithm and ListAlgorithms).
+// The options are applied after the algorithm is set. If name is not registered,
+// ErrUnknownAlgorithm is returned. AvailableAlgorithms returns the registered names.
+func NewHashByName(name string, opts ...Option) (*Hash, error) {
+	hs, err := NewHasher(Algorithm(name))
+	if err != nil {
+		return nil, err
+	}
+	return NewHash(append([]Option{WithUserDifinedAlgorithm(hs)}, opts...)...), nil
+}
+
+// NewHasher returns a new Hasher for algo, for use outside of a Hash, e.g. to compose it into another
+// pipeline. Aliases and algorithms added with Register are accepted as for NewHashByName.
+// If algo is not registered, ErrUnknownAlgorithm is returned.
+func NewHasher(algo Algorithm) (Hasher, error) {
+	registry.RLock()
+	factory, ok := registry.factories[resolveAlgorithmName(string(algo))]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algo)
+	}
+	return factory(), nil
+}
+
+// AvailableAlgorithms returns the names of the registered algorithms in alphabetical order.
+func AvailableAlgorithms() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,186 @@
+package hasher
+
+import (
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNewHashByName(t *testing.T) {
+	t.Parallel()
+
+	builtins := []string{
+		"md5", "sha1", "sha256", "sha512", "phash", "fnv32", "fnv32a", "fnv64", "fnv64a",
+		"fnv128", "fnv128a", "blake3", "adler32", "mmh3", "whirlpool", "crc32", "xxhash",
+		"crc8-smbus", "crc8-maxim", "sha3-256", "sha3-384", "sha3-512",
+		"blake2b-256", "blake2b-512", "blake2s-256", "crc32c", "crc32-koopman",
+		"crc64-iso", "crc64-ecma", "sha224", "sha384", "ripemd160",
+		"xxh3-64", "xxh3-128", "ahash", "dhash", "farmhash64",
+		"metrohash64", "metrohash128", "keccak256",
+	}
+	for _, name := range builtins {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			h, err := NewHashByName(name)
+			if err != nil {
+				t.Fatalf("NewHashByName() error = %v", err)
+			}
+			if got := algorithmName(h.hasher); got != name {
+				t.Errorf("algorithm name = %s, want %s", got, name)
+			}
+		})
+	}
+
+	h, err := NewHashByName("sha256")
+	if err != nil {
+		t.Fatalf("NewHashByName() error = %v", err)
+	}
+	got, err := h.Generate("test")
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if want := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"; hex.EncodeToString(got) != want {
+		t.Errorf("Hash.Generate() = %x, want %s", got, want)
+	}
+
+	if _, err := NewHashByName("unknown"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("NewHashByName() error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	const name = "test-user-hash"
+	if err := Register(name, func() Hasher { return &userHash{} }); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	defer Unregister(name)
+
+	h, err := NewHashByName(name)
+	if err != nil {
+		t.Fatalf("NewHashByName() error = %v", err)
+	}
+	got, err := h.Generate(strings.NewReader("anything"))
+	if err != nil {
+		t.Fatalf("Hash.Generate() error = %v", err)
+	}
+	if string(got) != "test" {
+		t.Errorf("Hash.Generate() = %q, want %q", got, "test")
+	}
+
+	names := AvailableAlgorithms()
+	if i := sort.SearchStrings(names, name); i == len(names) || names[i] != name {
+		t.Errorf("AvailableAlgorithms() = %v, want it to contain %s", names, name)
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("AvailableAlgorithms() = %v, want sorted names", names)
+	}
+
+	if err := Register(name, func() Hasher { return &userHash{} }); !errors.Is(err, ErrAlgorithmAlreadyRegistered) {
+		t.Errorf("Register() error = %v, want %v", err, ErrAlgorithmAlreadyRegistered)
+	}
+	if err := Register("sha256", newSHA256Hasher); !errors.Is(err, ErrAlgorithmAlreadyRegistered) {
+		t.Errorf("Register() error = %v, want %v", err, ErrAlgorithmAlreadyRegistered)
+	}
+	if err := Register("", newSHA256Hasher); !errors.Is(err, ErrInvalidAlgorithm) {
+		t.Errorf("Register() error = %v, want %v", err, ErrInvalidAlgorithm)
+	}
+	if err := Register("nil-factory", nil); !errors.Is(err, ErrInvalidAlgorithm) {
+		t.Errorf("Register() error = %v, want %v", err, ErrInvalidAlgorithm)
+	}
+
+	Unregister(name)
+	if _, err := NewHashByName(name); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("NewHashByName() error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
+
+func TestNewHashByName_Alias(t *testing.T) {
+	t.Parallel()
+
+	const want = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	for _, name := range []string{string(AlgoSHA256), AlgoSHA256.String(), "sha-256", "SHA256", "SHA-256"} {
+		h, err := NewHashByName(name)
+		if err != nil {
+			t.Fatalf("NewHashByName(%s) error = %v", name, err)
+		}
+		got, err := h.Generate("test")
+		if err != nil {
+			t.Fatalf("Hash.Generate() error = %v", err)
+		}
+		if hex.EncodeToString(got) != want {
+			t.Errorf("NewHashByName(%s).Generate() = %x, want %s", name, got, want)
+		}
+	}
+
+	if _, err := NewHashByName("sha-257"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("NewHashByName() error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
+
+func TestListAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	names := ListAlgorithms()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("ListAlgorithms() = %v, want sorted names", names)
+	}
+	for _, name := range []string{string(AlgoMD5), string(AlgoBlake3), "sha-1", "blake2b"} {
+		if i := sort.SearchStrings(names, name); i == len(names) || names[i] != name {
+			t.Errorf("ListAlgorithms() = %v, want it to contain %s", names, name)
+		}
+	}
+	for alias, algo := range algorithmAliases {
+		if _, err := NewHashByName(alias); err != nil {
+			t.Errorf("NewHashByName(%s) error = %v, want the alias of %s", alias, err, algo)
+		}
+	}
+}
+
+func TestNewHasher(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		algo Algorithm
+		opt  Option
+	}{
+		{algo: AlgoMD5, opt: WithMd5()},
+		{algo: AlgoSHA256, opt: WithSha256()},
+		{algo: AlgoSHA3_512, opt: WithSha3_512()},
+		{algo: AlgoBlake3, opt: WithBlake3()},
+		{algo: AlgoCRC32, opt: WithCRC32()},
+		{algo: "SHA-1", opt: WithSha1()},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.algo.String(), func(t *testing.T) {
+			t.Parallel()
+
+			hs, err := NewHasher(tt.algo)
+			if err != nil {
+				t.Fatalf("NewHasher() error = %v", err)
+			}
+			got, err := hs.GenHashFromString("test")
+			if err != nil {
+				t.Fatalf("Hasher.GenHashFromString() error = %v", err)
+			}
+
+			want, err := NewHash(tt.opt).Generate("test")
+			if err != nil {
+				t.Fatalf("Hash.Generate() error = %v", err)
+			}
+			if hex.EncodeToString(got) != hex.EncodeToString(want) {
+				t.Errorf("Hasher.GenHashFromString() = %x, want %x", got, want)
+			}
+		})
+	}
+
+	if _, err := NewHasher("no-such-algorithm"); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("NewHasher() error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
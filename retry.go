@@ -0,0 +1,85 @@
+package hasher
+
+import (
+	"errors"
+	"io"
+)
+
+// ReaderFunc opens a new io.Reader over the input to hash. Generate and Compare accept a ReaderFunc
+// as input: the reader is opened, hashed, and closed if it implements io.Closer. With WithRetry,
+// a new reader is opened for every attempt, which lets non-seekable inputs such as network
+// streams be retried. A ReaderFunc that returns a nil reader without an error makes hashing fail
+// with ErrNilReader.
+type ReaderFunc func() (io.Reader, error)
+
+// readErrorReader is an io.Reader that records the first error other than io.EOF returned by the underlying reader.
+type readErrorReader struct {
+	r   io.Reader
+	err error
+}
+
+// Read reads from the underlying reader and records read errors.
+func (e *readErrorReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && e.err == nil {
+		e.err = err
+	}
+	return n, err
+}
+
+// readWithRetry calls fn with a reader over the input. The input is r, or the readers opened by open if r is nil.
+// If reading the input fails, fn is called again with the input rewound (r must be an io.Seeker) or reopened,
+// up to the number of retries configured by WithRetry. Errors that are not caused by reading the input,
+// such as ErrHashMismatch, are returned without retrying.
+func (h *Hash) readWithRetry(r io.Reader, open ReaderFunc, fn func(io.Reader) ([]byte, error)) ([]byte, error) {
+	if r != nil && h.retries <= 0 {
+		return fn(r)
+	}
+
+	var (
+		seeker io.Seeker
+		start  int64
+	)
+	if r != nil {
+		s, ok := r.(io.Seeker)
+		if !ok {
+			return fn(r)
+		}
+		offset, err := s.Seek(0, io.SeekCurrent)
+		if err != nil {
+			// The reader claims to be seekable but is not, e.g. a pipe.
+			return fn(r)
+		}
+		seeker, start = s, offset
+	}
+
+	for attempt := 0; ; attempt++ {
+		rd := r
+		if open != nil {
+			var err error
+			if rd, err = open(); err != nil {
+				return nil, err
+			}
+			if rd == nil {
+				return nil, ErrNilReader
+			}
+		}
+
+		er := &readErrorReader{r: rd}
+		digest, err := fn(er)
+		if c, ok := rd.(io.Closer); ok && open != nil {
+			if cerr := c.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err == nil || er.err == nil || attempt >= h.retries {
+			return digest, err
+		}
+
+		if seeker != nil {
+			if _, serr := seeker.Seek(start, io.SeekStart); serr != nil {
+				return nil, err
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package hasher
+
+import (
+	"context"
+	"hash"
+	"io"
+	"sync"
+)
+
+// GenerateReused generates a hash from the input like Generate, but reuses hash.Hash instances from a pool
+// instead of allocating one per call, which reduces allocations when a server hashes many small inputs.
+// Pooled instances are reset before they are returned to the pool, and GenerateReused is safe for
+// concurrent use.
+// As with Generate, hashing an io.Reader stops with context.DeadlineExceeded once the duration set by
+// WithTimeout has passed.
+// If the algorithm is not backed by a hash.Hash (e.g. perceptual hash or an algorithm wrapped by an option
+// that transforms the input), or if WithCheckpoint or WithObserver is used, GenerateReused is the same as Generate.
+func (h *Hash) GenerateReused(input any) ([]byte, error) {
+	pool := h.reusePool()
+	if pool == nil || h.checkpoint != nil || h.observer != nil {
+		return h.Generate(input)
+	}
+
+	ctx := context.Background()
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	if s, ok := input.(string); ok {
+		return sumReused(pool, func(hs hash.Hash) error {
+			_, err := io.WriteString(hs, s)
+			return err
+		})
+	}
+	return h.generate(input, func(r io.Reader) ([]byte, error) {
+		return sumReused(pool, func(hs hash.Hash) error {
+			_, err := copyBuffer(hs, h.wrapReader(&contextReader{ctx: ctx, r: r}), h.bufferSize)
+			return err
+		})
+	})
+}
+
+// maxPooledBufferSize is the largest input buffer, in bytes, a pooled oneShotDigest may keep.
+// A oneShotDigest keeps its buffer after Reset, so one large input would otherwise pin that memory in the pool.
+const maxPooledBufferSize = 64 << 10
+
+// reusePool returns the pool of hash.Hash instances for GenerateReused, creating it on the first call.
+// It returns nil if the algorithm is not backed by a hash.Hash.
+func (h *Hash) reusePool() *sync.Pool {
+	if pool := h.hashPool.Load(); pool != nil {
+		return pool
+	}
+	s, ok := h.hasher.(streamer)
+	if !ok {
+		return nil
+	}
+	h.hashPool.CompareAndSwap(nil, &sync.Pool{New: func() any { return s.newHash() }})
+	return h.hashPool.Load()
+}
+
+// sumReused writes to a hash.Hash from pool with write and returns its sum.
+// The hash.Hash is reset and returned to the pool afterwards, unless it keeps a buffer
+// larger than maxPooledBufferSize.
+func sumReused(pool *sync.Pool, write func(hash.Hash) error) ([]byte, error) {
+	hs := pool.Get().(hash.Hash) //nolint:forcetypeassert
+	defer func() {
+		if d, ok := hs.(*oneShotDigest); ok && d.buf.Cap() > maxPooledBufferSize {
+			return
+		}
+		hs.Reset()
+		pool.Put(hs)
+	}()
+
+	if err := write(hs); err != nil {
+		return nil, err
+	}
+	return hs.Sum(nil), nil
+}
@@ -0,0 +1,10 @@
+package hasher
+
+import (
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // RIPEMD-160 is provided for interoperability only.
+)
+
+// newRipemd160Hasher creates a new Hasher instance for RIPEMD-160 algorithm.
+func newRipemd160Hasher() Hasher {
+	return &hasher{name: "ripemd160", HashFunc: ripemd160.New}
+}
@@ -0,0 +1,33 @@
+package hasher
+
+import (
+	"bytes"
+	"io"
+)
+
+// SaltMode is the position of the salt added by WithSalt.
+type SaltMode int
+
+const (
+	// SaltPrefix writes the salt before the input.
+	SaltPrefix SaltMode = iota
+	// SaltSuffix writes the salt after the input.
+	SaltSuffix
+)
+
+// newSaltHasher returns an inputHasher that hashes the input with salt before or after it.
+func newSaltHasher(base Hasher, salt []byte, mode SaltMode) *inputHasher {
+	salt = bytes.Clone(salt)
+	if mode != SaltSuffix {
+		return newPrefixHasher(base, func() []byte { return salt })
+	}
+	return &inputHasher{
+		base: base,
+		fromString: func(s string) (string, error) {
+			return s + string(salt), nil
+		},
+		fromReader: func(r io.Reader) (io.Reader, error) {
+			return io.MultiReader(r, bytes.NewReader(salt)), nil
+		},
+	}
+}
@@ -4,19 +4,97 @@ import (
 	"crypto/sha1" //nolint:gosec
 	"crypto/sha256"
 	"crypto/sha512"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // newSHA1Hasher creates a new Hasher instance for SHA-1 algorithm.
 func newSHA1Hasher() Hasher {
-	return &hasher{HashFunc: sha1.New}
+	return &hasher{name: "sha1", HashFunc: sha1.New}
 }
 
 // newSHA256Hasher creates a new Hasher instance for SHA-256 algorithm.
 func newSHA256Hasher() Hasher {
-	return &hasher{HashFunc: sha256.New}
+	return &hasher{name: "sha256", HashFunc: sha256.New}
+}
+
+// newSHA224Hasher creates a new Hasher instance for SHA-224 algorithm.
+func newSHA224Hasher() Hasher {
+	return &hasher{name: "sha224", HashFunc: sha256.New224}
+}
+
+// newSHA384Hasher creates a new Hasher instance for SHA-384 algorithm.
+func newSHA384Hasher() Hasher {
+	return &hasher{name: "sha384", HashFunc: sha512.New384}
 }
 
 // newSHA512Hasher creates a new Hasher instance for SHA-512 algorithm.
 func newSHA512Hasher() Hasher {
-	return &hasher{HashFunc: sha512.New}
+	return &hasher{name: "sha512", HashFunc: sha512.New}
+}
+
+// newSHA3_256Hasher creates a new Hasher instance for SHA3-256 algorithm.
+func newSHA3_256Hasher() Hasher {
+	return &hasher{name: "sha3-256", HashFunc: sha3.New256}
+}
+
+// newSHA3_384Hasher creates a new Hasher instance for SHA3-384 algorithm.
+func newSHA3_384Hasher() Hasher {
+	return &hasher{name: "sha3-384", HashFunc: sha3.New384}
+}
+
+// newSHA3_512Hasher creates a new Hasher instance for SHA3-512 algorithm.
+func newSHA3_512Hasher() Hasher {
+	return &hasher{name: "sha3-512", HashFunc: sha3.New512}
+}
+
+// newKeccak256Hasher creates a new Hasher instance for the original Keccak-256 algorithm,
+// which differs from SHA3-256 in its padding.
+func newKeccak256Hasher() Hasher {
+	return &hasher{name: "keccak256", HashFunc: sha3.NewLegacyKeccak256}
+}
+
+// newShake128Hasher creates a new Hasher instance for SHAKE128 with outLen bytes of output.
+func newShake128Hasher(outLen int) Hasher {
+	return newShakeHasher("shake128", sha3.NewShake128, outLen)
+}
+
+// newShake256Hasher creates a new Hasher instance for SHAKE256 with outLen bytes of output.
+func newShake256Hasher(outLen int) Hasher {
+	return newShakeHasher("shake256", sha3.NewShake256, outLen)
+}
+
+// newShakeHasher creates a new Hasher instance for a SHAKE extendable-output function with outLen bytes of output.
+// If outLen is not positive, the Hasher returns ErrInvalidOutputLength.
+func newShakeHasher(name string, newFunc func() sha3.ShakeHash, outLen int) Hasher {
+	if outLen <= 0 {
+		return &failingHasher{name: name, err: fmt.Errorf("%w: %d", ErrInvalidOutputLength, outLen)}
+	}
+	return &hasher{
+		name: name,
+		HashFunc: func() hash.Hash {
+			return &shakeDigest{ShakeHash: newFunc(), outLen: outLen}
+		},
+	}
+}
+
+// shakeDigest is a hash.Hash that reads outLen bytes of output from a SHAKE function.
+type shakeDigest struct {
+	sha3.ShakeHash
+	outLen int
+}
+
+// Size returns the length of the output in bytes.
+func (d *shakeDigest) Size() int {
+	return d.outLen
+}
+
+// Sum appends outLen bytes of output to b. It reads from a copy of the state,
+// so writing may continue after calling Sum.
+func (d *shakeDigest) Sum(b []byte) []byte {
+	out := make([]byte, d.outLen)
+	d.Clone().Read(out) //nolint:errcheck
+	return append(b, out...)
 }
@@ -0,0 +1,56 @@
+package hasher
+
+import (
+	"fmt"
+	"io"
+)
+
+// GenerateWithHumanSize generates a hash from the input, and returns it together with the
+// number of bytes hashed and that size formatted with IEC units, e.g. "4 B" or "1.2 MiB".
+// The input can be a string, a []byte, an io.Reader, or a ReaderFunc.
+// If hashing an io.Reader or a ReaderFunc is retried (see WithRetry), the bytes of the last attempt are counted.
+func (h *Hash) GenerateWithHumanSize(input any) (digest []byte, humanSize string, size int64, err error) {
+	var counter *countingReader
+	switch v := normalizeInput(input).(type) {
+	case string:
+		size = int64(len(v))
+	case io.Reader:
+		counter = &countingReader{r: v}
+		input = newCountingReader(counter)
+	case ReaderFunc:
+		input = ReaderFunc(func() (io.Reader, error) {
+			r, err := v()
+			if err != nil || r == nil {
+				// readWithRetry reports a nil reader.
+				return nil, err
+			}
+			counter = &countingReader{r: r}
+			return newCountingReader(counter), nil
+		})
+	}
+
+	digest, err = h.Generate(input)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	if counter != nil {
+		size = counter.n
+	}
+	return digest, formatIECSize(size), size, nil
+}
+
+// formatIECSize formats n bytes with IEC units (KiB, MiB, ...).
+// Sizes below 1 KiB are formatted as an integer number of bytes, e.g. "4 B".
+func formatIECSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,81 @@
+package hasher
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"time"
+)
+
+// tarEntry is the logical content of a tar archive entry.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	mode     int64
+	linkname string
+	digest   []byte
+}
+
+// GenerateTarContent generates a hash of the logical contents of the tar archive read from r.
+// The hash does not depend on the order of the entries or on volatile metadata such as
+// modification times, owners, or the tar format, so archives with the same files hash identically.
+//
+// Each entry's content is hashed with the configured algorithm. The entries are then sorted by
+// name, and the hash is generated from the concatenation of each entry's name, type, mode,
+// link target, and content hash, each length-prefixed to avoid ambiguity.
+// Entry names are cleaned with path.Clean, so "./a" and "a" are the same entry.
+func (h *Hash) GenerateTarContent(r io.Reader) ([]byte, error) {
+	cr := &countingReader{r: r}
+	defer func(start time.Time) { h.observeOperation(start, cr.n) }(time.Now())
+	inner := h.internal()
+
+	var entries []tarEntry
+	tr := tar.NewReader(cr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		digest, err := inner.Generate(tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, tarEntry{
+			name:     path.Clean(hdr.Name),
+			typeflag: hdr.Typeflag,
+			mode:     hdr.Mode,
+			linkname: hdr.Linkname,
+			digest:   digest,
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	var buf bytes.Buffer
+	for _, e := range entries {
+		writeLengthPrefixed(&buf, []byte(e.name))
+		buf.WriteByte(e.typeflag)
+		binary.Write(&buf, binary.BigEndian, e.mode) //nolint:errcheck // bytes.Buffer never fails.
+		writeLengthPrefixed(&buf, []byte(e.linkname))
+		writeLengthPrefixed(&buf, e.digest)
+	}
+	return inner.Generate(&buf)
+}
+
+// writeLengthPrefixed writes the length of b as a big-endian uint64 followed by b.
+func writeLengthPrefixed(buf *bytes.Buffer, b []byte) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(b)))
+	buf.Write(n[:])
+	buf.Write(b)
+}
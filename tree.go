@@ -0,0 +1,160 @@
+package hasher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Prefixes that separate the hashes of leaves from the hashes of parent nodes, as in RFC 6962.
+// Without them, a block holding the concatenation of two leaves would hash to their parent node,
+// so a one-block input could have the same root as a two-block input.
+const (
+	treeLeafPrefix = 0x00
+	treeNodePrefix = 0x01
+)
+
+// TreeHash splits r into blocks of leafSize bytes (the last one can be shorter) and returns the root of
+// the Merkle tree over them together with the hashes of the blocks, the leaves. As in RFC 6962, each leaf is
+// the hash of 0x00 followed by its block, so a single block can be verified with Compare(leaf, 0x00 || block),
+// and each parent node is the hash of 0x01 followed by the concatenation of its two children; a node without
+// a sibling is moved up to the next level as-is. The root therefore depends on leafSize. If r is empty, the root is the hash of the empty input and there
+// are no leaves. If leafSize is not positive, ErrInvalidLeafSize is returned.
+func (h *Hash) TreeHash(r io.Reader, leafSize int) (root []byte, leaves [][]byte, err error) {
+	if leafSize <= 0 {
+		return nil, nil, fmt.Errorf("%w: %d", ErrInvalidLeafSize, leafSize)
+	}
+
+	start, read := time.Now(), int64(0)
+	defer func() { h.observeOperation(start, read) }()
+	inner := h.internal()
+
+	block := make([]byte, 1+leafSize)
+	block[0] = treeLeafPrefix
+	for {
+		n, err := io.ReadFull(r, block[1:])
+		read += int64(n)
+		if n > 0 {
+			leaf, err := inner.Generate(block[:1+n])
+			if err != nil {
+				return nil, nil, err
+			}
+			leaves = append(leaves, leaf)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(leaves) == 0 {
+		root, err := inner.Generate("")
+		return root, nil, err
+	}
+
+	root, err = inner.merkleRoot(leaves)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, leaves, nil
+}
+
+// TreeHashAt returns the same root and leaves as TreeHash over the first size bytes of r, but hashes the
+// blocks in parallel. Linear algorithms such as SHA-256 process the input in order, so one digest cannot be
+// split across goroutines; only the independent blocks of a tree hash can. The blocks are hashed by as many
+// goroutines as set by WithConcurrency, or runtime.GOMAXPROCS(0) by default. r must be safe for concurrent
+// ReadAt calls, as *os.File is. If r ends before size bytes, io.ErrUnexpectedEOF is returned.
+// If leafSize is not positive, ErrInvalidLeafSize is returned.
+func (h *Hash) TreeHashAt(r io.ReaderAt, size int64, leafSize int) (root []byte, leaves [][]byte, err error) {
+	if leafSize <= 0 {
+		return nil, nil, fmt.Errorf("%w: %d", ErrInvalidLeafSize, leafSize)
+	}
+	inner := h.internal()
+	if size <= 0 {
+		defer h.observeOperation(time.Now(), 0)
+		root, err := inner.Generate("")
+		return root, nil, err
+	}
+	defer h.observeOperation(time.Now(), size)
+
+	count := int((size + int64(leafSize) - 1) / int64(leafSize))
+	leaves = make([][]byte, count)
+	errs := make([]error, count)
+
+	workers := h.concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > count {
+		workers = count
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			block := make([]byte, 1+leafSize)
+			block[0] = treeLeafPrefix
+			for i := range indexes {
+				off := int64(i) * int64(leafSize)
+				n := leafSize
+				if rest := size - off; rest < int64(n) {
+					n = int(rest)
+				}
+				if _, err := io.ReadFull(io.NewSectionReader(r, off, int64(n)), block[1:1+n]); err != nil {
+					if errors.Is(err, io.EOF) {
+						err = io.ErrUnexpectedEOF
+					}
+					errs[i] = err
+					continue
+				}
+				leaves[i], errs[i] = inner.Generate(block[:1+n])
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	root, err = inner.merkleRoot(leaves)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, leaves, nil
+}
+
+// merkleRoot returns the root of the Merkle tree over leaves, which must not be empty.
+func (h *Hash) merkleRoot(leaves [][]byte) ([]byte, error) {
+	level := leaves
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			node := make([]byte, 0, 1+len(level[i])+len(level[i+1]))
+			node = append(append(append(node, treeNodePrefix), level[i]...), level[i+1]...)
+			digest, err := h.Generate(node)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, digest)
+		}
+		level = next
+	}
+	return level[0], nil
+}
@@ -0,0 +1,91 @@
+package hasher
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"image"
+	"io"
+)
+
+// truncateHasher is a Hasher that returns the first n bytes of the hashes of the base hasher.
+type truncateHasher struct {
+	base Hasher
+	n    int
+}
+
+// newTruncateHasher creates a new truncateHasher. n is validated when a hash is generated,
+// because the length of the base hashes is not always known in advance.
+func newTruncateHasher(base Hasher, n int) *truncateHasher {
+	return &truncateHasher{base: base, n: n}
+}
+
+// Name returns the name of the base algorithm.
+func (t *truncateHasher) Name() string {
+	return algorithmName(t.base)
+}
+
+// unwrap returns the base hasher.
+func (t *truncateHasher) unwrap() Hasher {
+	return t.base
+}
+
+// size returns n, or 0 if n is not a valid length for the base algorithm.
+func (t *truncateHasher) size() int {
+	if full := digestSize(t.base); t.n <= 0 || (full > 0 && t.n > full) {
+		return 0
+	}
+	return t.n
+}
+
+// truncate returns the first n bytes of digest. If n is not positive or longer than digest,
+// ErrInvalidTruncation is returned.
+func (t *truncateHasher) truncate(digest []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if t.n <= 0 || t.n > len(digest) {
+		return nil, fmt.Errorf("%w: %d bytes, must be between 1 and %d bytes", ErrInvalidTruncation, t.n, len(digest))
+	}
+	return digest[:t.n], nil
+}
+
+// GenHashFromString generates the truncated hash of a string.
+func (t *truncateHasher) GenHashFromString(s string) ([]byte, error) {
+	return t.truncate(t.base.GenHashFromString(s))
+}
+
+// GenHashFromIOReader generates the truncated hash of an io.Reader.
+func (t *truncateHasher) GenHashFromIOReader(r io.Reader) ([]byte, error) {
+	return t.truncate(t.base.GenHashFromIOReader(r))
+}
+
+// genHashFromImage generates the truncated hash of a decoded image.
+func (t *truncateHasher) genHashFromImage(img image.Image) ([]byte, error) {
+	return t.truncate(hashImage(t.base, img))
+}
+
+// CmpHashAndString compares a hash and the truncated hash of a string.
+func (t *truncateHasher) CmpHashAndString(hashA []byte, s string) error {
+	hashB, err := t.GenHashFromString(s)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// CmpHashAndIOReader compares a hash and the truncated hash of an io.Reader.
+func (t *truncateHasher) CmpHashAndIOReader(hashA []byte, r io.Reader) error {
+	hashB, err := t.GenHashFromIOReader(r)
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(hashA, hashB) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
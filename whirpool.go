@@ -4,5 +4,5 @@ import "github.com/jzelinskie/whirlpool"
 
 // newWhirlpoolHasher creates a new Hasher instance for Whirlpool algorithm.
 func newWhirlpoolHasher() Hasher {
-	return &hasher{HashFunc: whirlpool.New}
+	return &hasher{name: "whirlpool", HashFunc: whirlpool.New}
 }
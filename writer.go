@@ -0,0 +1,65 @@
+package hasher
+
+import (
+	"encoding"
+	"fmt"
+	"hash"
+)
+
+// DigestWriter is an io.Writer that hashes everything written to it, so content can be hashed
+// incrementally, e.g. with io.Copy in chunks or as the target of io.MultiWriter.
+// A DigestWriter is not safe for concurrent use.
+type DigestWriter struct {
+	hash hash.Hash
+}
+
+// Write adds p to the running hash. It never returns an error.
+func (w *DigestWriter) Write(p []byte) (int, error) {
+	return w.hash.Write(p)
+}
+
+// Sum returns the hash of the bytes written so far. It does not change the state,
+// so writing may continue after calling Sum.
+func (w *DigestWriter) Sum() []byte {
+	return w.hash.Sum(nil)
+}
+
+// Reset discards the bytes written so far.
+func (w *DigestWriter) Reset() {
+	w.hash.Reset()
+}
+
+// SaveState returns the state of the running hash, so hashing can be resumed later with RestoreState,
+// e.g. in another process. The state is only valid for the same algorithm.
+// If the algorithm cannot save its state, ErrStateNotSupported is returned.
+func (w *DigestWriter) SaveState() ([]byte, error) {
+	m, ok := w.hash.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrStateNotSupported, w.hash)
+	}
+	return m.MarshalBinary()
+}
+
+// RestoreState replaces the state of the running hash with a state returned by SaveState,
+// so writing continues where the saved DigestWriter stopped.
+// If the algorithm cannot restore its state, ErrStateNotSupported is returned.
+func (w *DigestWriter) RestoreState(state []byte) error {
+	u, ok := w.hash.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrStateNotSupported, w.hash)
+	}
+	return u.UnmarshalBinary(state)
+}
+
+// Writer returns a DigestWriter that hashes the bytes written to it with the configured algorithm.
+// The digest returned by Sum equals the result of Generate over the same bytes. A new DigestWriter is returned
+// on every call, so it can be plugged into io.TeeReader or io.MultiWriter to hash data while it is processed elsewhere.
+// If the algorithm cannot hash incrementally (e.g. perceptual hash, bcrypt, or an algorithm wrapped
+// by an option that transforms the input), ErrStreamingNotSupported is returned.
+func (h *Hash) Writer() (*DigestWriter, error) {
+	s, ok := h.hasher.(streamer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrStreamingNotSupported, algorithmName(h.hasher))
+	}
+	return &DigestWriter{hash: s.newHash()}, nil
+}
@@ -1,10 +1,12 @@
 package hasher
 
 import (
-	"github.com/cespare/xxhash"
+	"hash"
+
+	"github.com/cespare/xxhash/v2"
 )
 
 // newXXHasher creates a new Hasher instance for XXHash algorithm.
 func newXXHasher() Hasher {
-	return &hasher64{HashFunc: xxhash.New}
+	return &hasher64{name: "xxhash", HashFunc: func() hash.Hash64 { return xxhash.New() }}
 }
@@ -0,0 +1,33 @@
+package hasher
+
+import (
+	"hash"
+
+	"github.com/zeebo/xxh3"
+)
+
+// newXXH3_64Hasher creates a new Hasher instance for XXH3-64 algorithm.
+func newXXH3_64Hasher() Hasher { //nolint:revive,stylecheck
+	return &hasher{name: "xxh3-64", HashFunc: func() hash.Hash { return xxh3.New() }}
+}
+
+// newXXH3_128Hasher creates a new Hasher instance for XXH3-128 algorithm.
+func newXXH3_128Hasher() Hasher { //nolint:revive,stylecheck
+	return &hasher{name: "xxh3-128", HashFunc: func() hash.Hash { return &xxh3Digest128{xxh3.New()} }}
+}
+
+// xxh3Digest128 is a hash.Hash that returns the 128-bit XXH3 digest in big-endian (canonical) order.
+type xxh3Digest128 struct {
+	*xxh3.Hasher
+}
+
+// Size returns the length of the digest in bytes.
+func (d *xxh3Digest128) Size() int {
+	return 16
+}
+
+// Sum appends the 128-bit digest to b.
+func (d *xxh3Digest128) Sum(b []byte) []byte {
+	sum := d.Sum128().Bytes()
+	return append(b, sum[:]...)
+}